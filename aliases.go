@@ -16,6 +16,7 @@ type (
 	Router        = *routing.Router
 	Aggregation   = *aggregation.Aggregation
 	HTTPException = *exception.HTTPException
+	Reflector     = core.Reflector
 
 	// decorators
 	Context    = *ctx.Context
@@ -26,8 +27,13 @@ type (
 	File       = ctx.File
 	Query      = ctx.Query
 	Header     = ctx.Header
+	Cookie     = ctx.Cookie
 	Param      = ctx.Param
 	WSPayload  = ctx.WSPayload
+	SSEStream  = *ctx.SSEStream
+	SSEEvent   = ctx.SSEEvent
+	Download   = ctx.Download
+	CSRFToken  = ctx.CSRFToken
 	Next       = ctx.Next
 	Redirect   = ctx.Redirect
 	FieldLevel = ctx.FieldLevel
@@ -68,18 +68,13 @@ func (e *ExceptionFilter) InjectProvidersIntoRESTExceptionFilters(r *REST, cb fu
 
 		shouldAddExceptionFilter := map[string]bool{}
 		for _, handler := range exceptionFilterHandler.Handlers {
-			fnName := GetFnName(handler)
-			method, route := ParseFnNameToURL(fnName, RESTOperations)
-			httpMethod := routing.OperationsMapHTTPMethods[method]
-
-			route = r.addPrefixesToRoute(route, fnName, r.GetPrefixes())
-			shouldAddExceptionFilter[routing.AddMethodToRoute(route, httpMethod)] = true
+			shouldAddExceptionFilter[GetFnName(handler)] = true
 		}
 
 		for pattern := range r.PatternToFnNameMap {
-			if _, ok := shouldAddExceptionFilter[pattern]; ok || len(shouldAddExceptionFilter) == 0 {
+			if _, ok := shouldAddExceptionFilter[r.PatternToFnNameMap[pattern]]; ok || len(shouldAddExceptionFilter) == 0 {
 				method, route := routing.SplitRoute(pattern)
-				httpMethod := routing.OperationsMapHTTPMethods[method]
+				httpMethod := routing.ToHTTPMethod(method)
 
 				exceptionFilterItemArr = append(exceptionFilterItemArr, ExceptionFilterItem{
 					Method:  httpMethod,
@@ -20,7 +20,58 @@ func GetFnName(handler any) string {
 	return fnName[:len(fnName)-3]
 }
 
-func ParseFnNameToURL(fnName string, operations map[string]string) (string, string) {
+// GetHandlerName returns package qualified name of handler
+// e.g. github.com/user/app/users.UserController.READ_users
+func GetHandlerName(handler any) string {
+	return strings.TrimSuffix(runtime.FuncForPC(reflect.ValueOf(handler).Pointer()).Name(), "-fm")
+}
+
+// NamingStrategy converts static path segment
+// of function name e.g. user_profiles,
+// params are never converted
+type NamingStrategy func(string) string
+
+// SnakeCase keeps segment as declared e.g. user_profiles
+func SnakeCase(segment string) string {
+	return segment
+}
+
+// KebabCase converts segment e.g. user_profiles to user-profiles
+func KebabCase(segment string) string {
+	return strings.ReplaceAll(segment, "_", "-")
+}
+
+// CamelCase converts segment e.g. user_profiles to userProfiles
+func CamelCase(segment string) string {
+	words := strings.Split(segment, "_")
+	for i := 1; i < len(words); i++ {
+		if words[i] != "" {
+			words[i] = strings.ToUpper(words[i][:1]) + words[i][1:]
+		}
+	}
+
+	return strings.Join(words, "")
+}
+
+// applyNamingStrategy converts static parts of segment,
+// wildcards and file extension are kept
+func applyNamingStrategy(segment string, namingStrategy NamingStrategy) string {
+	name, extension := segment, ""
+	if lastDotIndex := strings.LastIndex(segment, "."); lastDotIndex > -1 {
+		name, extension = segment[:lastDotIndex], segment[lastDotIndex:]
+	}
+
+	parts := strings.Split(name, "*")
+	for i, part := range parts {
+		if part != "" {
+			parts[i] = namingStrategy(part)
+		}
+	}
+
+	return strings.Join(parts, "*") + extension
+}
+
+func ParseFnNameToURL(fnName string, operations map[string]string, namingStrategy ...NamingStrategy) (string, string) {
 	method := ""
 	route := ""
 
@@ -93,6 +144,10 @@ func ParseFnNameToURL(fnName string, operations map[string]string) (string, stri
 			}
 			j = i
 
+			if len(namingStrategy) > 0 && namingStrategy[0] != nil {
+				path = applyNamingStrategy(path, namingStrategy[0])
+			}
+
 			route = path + "/" + route
 			continue
 		}
@@ -67,18 +67,13 @@ func (g *Guard) InjectProvidersIntoRESTGuards(r *REST, cb func(int, reflect.Type
 
 		shouldAddGuard := map[string]bool{}
 		for _, handler := range guardHandler.Handlers {
-			fnName := GetFnName(handler)
-			method, route := ParseFnNameToURL(fnName, RESTOperations)
-			httpMethod := routing.OperationsMapHTTPMethods[method]
-
-			route = r.addPrefixesToRoute(route, fnName, r.GetPrefixes())
-			shouldAddGuard[routing.AddMethodToRoute(route, httpMethod)] = true
+			shouldAddGuard[GetFnName(handler)] = true
 		}
 
 		for pattern := range r.PatternToFnNameMap {
-			if _, ok := shouldAddGuard[pattern]; ok || len(shouldAddGuard) == 0 {
+			if _, ok := shouldAddGuard[r.PatternToFnNameMap[pattern]]; ok || len(shouldAddGuard) == 0 {
 				method, route := routing.SplitRoute(pattern)
-				httpMethod := routing.OperationsMapHTTPMethods[method]
+				httpMethod := routing.ToHTTPMethod(method)
 
 				guardItemArr = append(guardItemArr, GuardItem{
 					Method:  httpMethod,
@@ -69,18 +69,13 @@ func (i *Interceptor) InjectProvidersIntoRESTInterceptors(r *REST, cb func(int,
 
 		shouldAddInterceptors := map[string]bool{}
 		for _, handler := range interceptorHandler.Handlers {
-			fnName := GetFnName(handler)
-			method, route := ParseFnNameToURL(fnName, RESTOperations)
-			httpMethod := routing.OperationsMapHTTPMethods[method]
-
-			route = r.addPrefixesToRoute(route, fnName, r.GetPrefixes())
-			shouldAddInterceptors[routing.AddMethodToRoute(route, httpMethod)] = true
+			shouldAddInterceptors[GetFnName(handler)] = true
 		}
 
 		for pattern := range r.PatternToFnNameMap {
-			if _, ok := shouldAddInterceptors[pattern]; ok || len(shouldAddInterceptors) == 0 {
+			if _, ok := shouldAddInterceptors[r.PatternToFnNameMap[pattern]]; ok || len(shouldAddInterceptors) == 0 {
 				method, route := routing.SplitRoute(pattern)
-				httpMethod := routing.OperationsMapHTTPMethods[method]
+				httpMethod := routing.ToHTTPMethod(method)
 
 				interceptorItemArr = append(interceptorItemArr, InterceptorItem{
 					Method:  httpMethod,
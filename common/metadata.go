@@ -0,0 +1,101 @@
+package common
+
+import (
+	"github.com/dangduoc08/gogo/routing"
+)
+
+type MetadataHandler struct {
+	Key      string
+	Value    any
+	Handlers []any
+}
+
+type MetadataItem struct {
+
+	// for REST
+	Method string
+	Route  string
+
+	// for WS
+	EventName string
+
+	Key   string
+	Value any
+
+	// metadata was set for handler
+	// rather than whole controller
+	IsHandler bool
+}
+
+type Metadata struct {
+	MetadataHandlers []MetadataHandler
+}
+
+// SetMetadata attaches value to handlers,
+// value is attached to all controller handlers
+// if no handler was passed
+func (m *Metadata) SetMetadata(k string, v any, handlers ...any) *Metadata {
+	metadataHandler := MetadataHandler{
+		Key:      k,
+		Value:    v,
+		Handlers: handlers,
+	}
+
+	m.MetadataHandlers = append(m.MetadataHandlers, metadataHandler)
+	return m
+}
+
+func (m *Metadata) GetRESTMetadata(r *REST) []MetadataItem {
+	metadataItemArr := []MetadataItem{}
+
+	for _, metadataHandler := range m.MetadataHandlers {
+		shouldAddMetadata := map[string]bool{}
+		for _, handler := range metadataHandler.Handlers {
+			shouldAddMetadata[GetFnName(handler)] = true
+		}
+
+		for pattern := range r.PatternToFnNameMap {
+			if _, ok := shouldAddMetadata[r.PatternToFnNameMap[pattern]]; ok || len(shouldAddMetadata) == 0 {
+				method, route := routing.SplitRoute(pattern)
+				httpMethod := routing.ToHTTPMethod(method)
+
+				metadataItemArr = append(metadataItemArr, MetadataItem{
+					Method:    httpMethod,
+					Route:     routing.ToEndpoint(route),
+					Key:       metadataHandler.Key,
+					Value:     metadataHandler.Value,
+					IsHandler: len(shouldAddMetadata) > 0,
+				})
+			}
+		}
+	}
+
+	return metadataItemArr
+}
+
+func (m *Metadata) GetWSMetadata(ws *WS) []MetadataItem {
+	metadataItemArr := []MetadataItem{}
+
+	for _, metadataHandler := range m.MetadataHandlers {
+		shouldAddMetadata := map[string]bool{}
+		for _, handler := range metadataHandler.Handlers {
+			fnName := GetFnName(handler)
+			_, eventName := ParseFnNameToURL(fnName, WSOperations)
+			eventName = ToWSEventName(ws.GetSubprotocol(), eventName)
+			shouldAddMetadata[eventName] = true
+		}
+
+		for pattern := range ws.patternToFnNameMap {
+			if _, ok := shouldAddMetadata[pattern]; ok || len(shouldAddMetadata) == 0 {
+				metadataItemArr = append(metadataItemArr, MetadataItem{
+					EventName: pattern,
+					Key:       metadataHandler.Key,
+					Value:     metadataHandler.Value,
+					IsHandler: len(shouldAddMetadata) > 0,
+				})
+			}
+		}
+	}
+
+	return metadataItemArr
+}
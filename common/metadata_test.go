@@ -0,0 +1,44 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/dangduoc08/gogo/utils"
+)
+
+type metadataController struct{}
+
+func (instance metadataController) READ_users() {}
+
+func (instance metadataController) DELETE_users_BY_id() {}
+
+func TestMetadataGetRESTMetadata(t *testing.T) {
+	controller := metadataController{}
+	rest := REST{}
+	rest.AddHandlerToRouterMap([]string{}, "READ_users", controller.READ_users)
+	rest.AddHandlerToRouterMap([]string{}, "DELETE_users_BY_id", controller.DELETE_users_BY_id)
+
+	metadata := Metadata{}
+	metadata.
+		SetMetadata("roles", []string{"user"}).
+		SetMetadata("roles", []string{"admin"}, controller.DELETE_users_BY_id)
+
+	metadataItems := metadata.GetRESTMetadata(&rest)
+	if len(metadataItems) != 3 {
+		t.Fatalf(utils.ErrorMessage(len(metadataItems), 3, "metadata items should be equal"))
+	}
+
+	for _, metadataItem := range metadataItems {
+		if !metadataItem.IsHandler {
+			continue
+		}
+
+		if metadataItem.Method != "DELETE" || metadataItem.Route != "/users/{id}/" {
+			t.Errorf(utils.ErrorMessage(metadataItem.Method+" "+metadataItem.Route, "DELETE /users/{id}/", "handler metadata should be set for bound handler"))
+		}
+
+		if roles := metadataItem.Value.([]string); roles[0] != "admin" {
+			t.Errorf(utils.ErrorMessage(roles[0], "admin", "handler metadata should be equal"))
+		}
+	}
+}
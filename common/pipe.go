@@ -22,6 +22,10 @@ type HeaderPipeable interface {
 	Transform(ctx.Header, ArgumentMetadata) any
 }
 
+type CookiePipeable interface {
+	Transform(ctx.Cookie, ArgumentMetadata) any
+}
+
 type ParamPipeable interface {
 	Transform(ctx.Param, ArgumentMetadata) any
 }
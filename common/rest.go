@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"runtime"
+	"strings"
 
 	"github.com/dangduoc08/gogo/routing"
 	"github.com/dangduoc08/gogo/utils"
@@ -20,6 +22,68 @@ var RESTOperations = map[string]string{
 
 var InsertedRoutes = make(map[string]string)
 
+// AddRESTOperation maps operation word of function names
+// to HTTP method e.g. SEARCH to SEARCH or LIST to GET
+// in operations of App, which are cloned from RESTOperations.
+// it returns normalized method to be registered to router
+func AddRESTOperation(operations map[string]string, operation, method string) string {
+	method = strings.ToUpper(strings.TrimSpace(method))
+
+	if !isOperationWord(operation) || TokenMap[operation] != "" || operation == routing.SERVE {
+		panic(fmt.Errorf(
+			utils.FmtRed(
+				"invalid REST operation '%v'",
+				operation,
+			),
+		))
+	}
+
+	if !isOperationWord(method) || method == routing.SERVE {
+		panic(fmt.Errorf(
+			utils.FmtRed(
+				"invalid HTTP method '%v' of %v operation",
+				method,
+				operation,
+			),
+		))
+	}
+
+	if registeredMethod, ok := operations[operation]; ok && registeredMethod != method {
+		panic(fmt.Errorf(
+			utils.FmtRed(
+				"%v operation is already mapped to %v method",
+				operation,
+				registeredMethod,
+			),
+		))
+	}
+
+	operations[operation] = method
+
+	return method
+}
+
+// isOperationWord reports whether s
+// contains upper case letters only
+// to be split from function names
+func isOperationWord(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// VERSION_NEUTRAL handlers are shared across versions,
+// they are matched by any requested version
+const VERSION_NEUTRAL = "VERSION_NEUTRAL"
+
 const (
 	TOKEN_BY   = "BY"
 	TOKEN_AND  = "AND"
@@ -44,6 +108,13 @@ type RESTConfiguration struct {
 
 type REST struct {
 	prefixes           []Prefix
+	host               string
+	versions           []Version
+	routes             []Route
+	namingStrategy     NamingStrategy
+	operations         map[string]string
+	constraints        []ConstraintHandler
+	urlReferences      []any
 	PatternToFnNameMap map[string]string
 	RouterMap          map[string]any
 }
@@ -53,6 +124,30 @@ type Prefix struct {
 	Handlers []any
 }
 
+type Route struct {
+	Method  string
+	Path    string
+	Handler any
+}
+
+type Version struct {
+	Value    string
+	Handlers []any
+}
+
+type ConstraintHandler struct {
+	Key        string
+	Constraint routing.Constraint
+	Handlers   []any
+}
+
+type ConstraintItem struct {
+	Method     string
+	Route      string
+	Key        string
+	Constraint routing.Constraint
+}
+
 func (r *REST) addToRouters(fnName, path, method string, injectableHandler any) {
 	if reflect.ValueOf(r.RouterMap).IsNil() {
 		r.RouterMap = make(map[string]any)
@@ -115,17 +210,319 @@ func (r *REST) Prefix(v string, handlers ...any) *REST {
 	return r
 }
 
+// Host binds controller routes to host pattern
+// e.g. {tenant}.example.com,
+// host params are read as route params
+func (r *REST) Host(host string) *REST {
+	r.host = host
+
+	return r
+}
+
+func (r *REST) GetHost() string {
+	return r.host
+}
+
+// NamingStrategy converts static segments
+// of function name routes e.g. common.KebabCase,
+// it takes precedence over app naming strategy
+func (r *REST) NamingStrategy(namingStrategy NamingStrategy) *REST {
+	r.namingStrategy = namingStrategy
+
+	return r
+}
+
+func (r *REST) GetNamingStrategy() NamingStrategy {
+	return r.namingStrategy
+}
+
+// Operations sets operations of App
+// which function names are parsed by
+func (r *REST) Operations(operations map[string]string) *REST {
+	r.operations = operations
+
+	return r
+}
+
+// GetOperations returns RESTOperations
+// unless App operations were set
+func (r *REST) GetOperations() map[string]string {
+	if r.operations == nil {
+		return RESTOperations
+	}
+
+	return r.operations
+}
+
+// Get registers controller method as GET handler of path
+// e.g. instance.Get("/user-profiles/{id}", instance.FindProfile),
+// explicit routes can be mixed with function name routes.
+// params must take whole segments,
+// paths like /files/{name}.json are rejected
+func (r *REST) Get(path string, handler any) *REST {
+	return r.addRoute(path, handler, http.MethodGet)
+}
+
+func (r *REST) Post(path string, handler any) *REST {
+	return r.addRoute(path, handler, http.MethodPost)
+}
+
+func (r *REST) Put(path string, handler any) *REST {
+	return r.addRoute(path, handler, http.MethodPut)
+}
+
+func (r *REST) Patch(path string, handler any) *REST {
+	return r.addRoute(path, handler, http.MethodPatch)
+}
+
+func (r *REST) Delete(path string, handler any) *REST {
+	return r.addRoute(path, handler, http.MethodDelete)
+}
+
+// Any registers controller method
+// as handler of all REST operation methods
+func (r *REST) Any(path string, handler any) *REST {
+	return r.addRoute(
+		path,
+		handler,
+		http.MethodGet,
+		http.MethodPost,
+		http.MethodPut,
+		http.MethodPatch,
+		http.MethodDelete,
+	)
+}
+
+// GetRoutes returns explicitly registered routes
+func (r *REST) GetRoutes() []Route {
+	return r.routes
+}
+
+func (r *REST) addRoute(path string, handler any, methods ...string) *REST {
+	// handlers must be controller methods
+	// to be injected and bound by name
+	if handler == nil ||
+		reflect.TypeOf(handler).Kind() != reflect.Func ||
+		!strings.HasSuffix(runtime.FuncForPC(reflect.ValueOf(handler).Pointer()).Name(), "-fm") {
+		panic(fmt.Errorf(
+			utils.FmtRed(
+				"handler of %v route must be controller method",
+				path,
+			),
+		))
+	}
+
+	// trie matches params by whole segments,
+	// partial params would never be matched
+	for _, seg := range strings.Split(path, "/") {
+		if strings.ContainsAny(seg, "{}") &&
+			(!strings.HasPrefix(seg, "{") ||
+				!strings.HasSuffix(seg, "}") ||
+				strings.ContainsAny(seg[1:len(seg)-1], "{}") ||
+				len(seg) == 2) {
+			panic(fmt.Errorf(
+				utils.FmtRed(
+					"%v segment of %v route must be whole param e.g. {id}",
+					seg,
+					path,
+				),
+			))
+		}
+	}
+
+	for _, method := range methods {
+		r.routes = append(r.routes, Route{
+			Method:  method,
+			Path:    path,
+			Handler: handler,
+		})
+	}
+
+	return r
+}
+
+// Version binds handlers to API version,
+// all controller routes are bound if no handler was passed.
+// versions of handler override controller versions,
+// handlers may be bound to several versions
+func (r *REST) Version(v string, handlers ...any) *REST {
+	v = strings.Trim(strings.TrimSpace(v), "/")
+	if v == "" || strings.Contains(v, "/") {
+		panic(fmt.Errorf(
+			utils.FmtRed(
+				"invalid version '%v'",
+				v,
+			),
+		))
+	}
+
+	r.versions = append(r.versions, Version{
+		Value:    v,
+		Handlers: handlers,
+	})
+
+	return r
+}
+
+// GetVersions returns versions of handler,
+// empty versions mean handler isn't versioned
+func (r *REST) GetVersions(fnName string) []string {
+	controllerVersions := []string{}
+	handlerVersions := []string{}
+
+	for _, version := range r.versions {
+		if len(version.Handlers) == 0 {
+			controllerVersions = appendVersion(controllerVersions, version.Value)
+			continue
+		}
+
+		for _, handler := range version.Handlers {
+			if GetFnName(handler) == fnName {
+				handlerVersions = appendVersion(handlerVersions, version.Value)
+			}
+		}
+	}
+
+	if len(handlerVersions) > 0 {
+		return handlerVersions
+	}
+
+	return controllerVersions
+}
+
+func appendVersion(versions []string, version string) []string {
+	for _, v := range versions {
+		if v == version {
+			return versions
+		}
+	}
+
+	return append(versions, version)
+}
+
+// Constraint restricts param k of handlers,
+// constraint is applied to all controller routes
+// which contain param k if no handler was passed
+func (r *REST) Constraint(k string, constraint routing.Constraint, handlers ...any) *REST {
+	r.constraints = append(r.constraints, ConstraintHandler{
+		Key:        k,
+		Constraint: constraint,
+		Handlers:   handlers,
+	})
+
+	return r
+}
+
+func (r *REST) GetConstraints() []ConstraintItem {
+	constraintItemArr := []ConstraintItem{}
+
+	for _, constraintHandler := range r.constraints {
+		shouldAddConstraint := map[string]bool{}
+		for _, handler := range constraintHandler.Handlers {
+			shouldAddConstraint[GetFnName(handler)] = true
+		}
+
+		for pattern, fnName := range r.PatternToFnNameMap {
+			if _, ok := shouldAddConstraint[fnName]; !ok && len(shouldAddConstraint) > 0 {
+				continue
+			}
+
+			method, route := routing.SplitRoute(pattern)
+			route = routing.ToEndpoint(route)
+
+			if !strings.Contains(route, "{"+constraintHandler.Key+"}") {
+				if len(shouldAddConstraint) > 0 {
+					panic(fmt.Errorf(
+						utils.FmtRed(
+							"%v method has no %v param to be constrained",
+							fnName,
+							constraintHandler.Key,
+						),
+					))
+				}
+				continue
+			}
+
+			constraintItemArr = append(constraintItemArr, ConstraintItem{
+				Method:     method,
+				Route:      route,
+				Key:        constraintHandler.Key,
+				Constraint: constraintHandler.Constraint,
+			})
+		}
+	}
+
+	return constraintItemArr
+}
+
+// RequireURLs declares handlers
+// which URLs are built by controller,
+// app creation fails if any of them
+// wasn't registered as REST handler
+func (r *REST) RequireURLs(handlers ...any) *REST {
+	r.urlReferences = append(r.urlReferences, handlers...)
+
+	return r
+}
+
+// GetURLReferences returns package qualified names
+// of handlers which were required by RequireURLs
+func (r *REST) GetURLReferences() []string {
+	handlerNames := []string{}
+	for _, handler := range r.urlReferences {
+		if handler == nil || reflect.TypeOf(handler).Kind() != reflect.Func {
+			panic(fmt.Errorf(utils.FmtRed("URL reference must be function, got %T", handler)))
+		}
+
+		handlerNames = append(handlerNames, GetHandlerName(handler))
+	}
+
+	return handlerNames
+}
+
 func (r *REST) AddHandlerToRouterMap(modulePrefixes []string, fnName string, handler any) {
 	prefixes := r.GetPrefixes()
 
-	httpMethod, route := ParseFnNameToURL(fnName, RESTOperations)
+	httpMethod, route := ParseFnNameToURL(fnName, r.GetOperations(), r.namingStrategy)
 	if httpMethod != "" {
-		route = r.addPrefixesToRoute(route, fnName, prefixes)
-		for _, modulePrefix := range modulePrefixes {
-			route = modulePrefix + route
+		r.addRouteToRouterMap(modulePrefixes, prefixes, fnName, httpMethod, route, handler)
+	}
+
+	// explicitly registered routes
+	// are added next to function name routes
+	for _, explicitRoute := range r.routes {
+		if GetFnName(explicitRoute.Handler) == fnName {
+			r.addRouteToRouterMap(modulePrefixes, prefixes, fnName, explicitRoute.Method, routing.ToEndpoint(explicitRoute.Path), handler)
+		}
+	}
+}
+
+func (r *REST) addRouteToRouterMap(modulePrefixes []string, prefixes []map[string]string, fnName, httpMethod, route string, handler any) {
+	route = r.addPrefixesToRoute(route, fnName, prefixes)
+	for _, modulePrefix := range modulePrefixes {
+		route = modulePrefix + route
+	}
+
+	versions := r.GetVersions(fnName)
+	if len(versions) == 0 {
+		versions = []string{VERSION_NEUTRAL}
+	}
+
+	for _, version := range versions {
+		versionRoute := route
+
+		// neutral routes have no version segment
+		// and are matched after versioned routes
+		if version != VERSION_NEUTRAL {
+			versionRoute = routing.VersionToRoute(version) + versionRoute
+		}
+
+		// host is matched before version and path
+		if r.host != "" {
+			versionRoute = routing.HostToRoute(r.host) + versionRoute
 		}
 
-		routeMethod := routing.AddMethodToRoute(routing.ToEndpoint(route), httpMethod)
+		routeMethod := routing.AddMethodToRoute(routing.ToEndpoint(versionRoute), httpMethod)
 		if InsertedRoutes[routeMethod] == "" {
 			InsertedRoutes[routeMethod] = fnName
 		} else {
@@ -138,7 +535,7 @@ func (r *REST) AddHandlerToRouterMap(modulePrefixes []string, fnName string, han
 			))
 		}
 
-		r.addToRouters(fnName, route, httpMethod, handler)
+		r.addToRouters(fnName, versionRoute, httpMethod, handler)
 	}
 }
 
@@ -1,8 +1,11 @@
 package common
 
 import (
+	"maps"
+	"strings"
 	"testing"
 
+	"github.com/dangduoc08/gogo/routing"
 	"github.com/dangduoc08/gogo/utils"
 )
 
@@ -60,3 +63,249 @@ func TestParseFnNameToURL(t *testing.T) {
 		}
 	}
 }
+
+func TestParseFnNameToURLNamingStrategy(t *testing.T) {
+	testCases := map[string][]string{
+		"READ_user_profiles_BY_profile_id":             {"/user-profiles/{profile_id}/", "/userProfiles/{profile_id}/", "/user_profiles/{profile_id}/"},
+		"READ_me_ANY_bers_OF_us_ANY_ers_BY_user_id":    {"/us*ers/{user_id}/me*bers/", "/us*ers/{user_id}/me*bers/", "/us*ers/{user_id}/me*bers/"},
+		"DELETE_user_avatar_PNG_FILE_OF_team_members":  {"/team-members/user-avatar.png/", "/teamMembers/userAvatar.png/", "/team_members/user_avatar.png/"},
+		"READ_ANY_HTML_FILE_OF_static_files":           {"/static-files/*.html/", "/staticFiles/*.html/", "/static_files/*.html/"},
+		"READ_ANY_OF_audit_logs_BY_log_id_AND_item_id": {"/audit-logs/{log_id}/{item_id}/*/", "/auditLogs/{log_id}/{item_id}/*/", "/audit_logs/{log_id}/{item_id}/*/"},
+	}
+
+	for fn, results := range testCases {
+		for i, namingStrategy := range []NamingStrategy{KebabCase, CamelCase, SnakeCase} {
+			_, route := ParseFnNameToURL(fn, RESTOperations, namingStrategy)
+			if route != results[i] {
+				t.Errorf(utils.ErrorMessage(route, results[i], "named route should be equal"))
+			}
+		}
+	}
+
+	_, route := ParseFnNameToURL("READ_user_profiles", RESTOperations, strings.ToUpper)
+	if route != "/USER_PROFILES/" {
+		t.Errorf(utils.ErrorMessage(route, "/USER_PROFILES/", "custom named route should be equal"))
+	}
+}
+
+type constraintController struct{}
+
+func (instance constraintController) READ_posts_BY_id() {}
+
+func (instance constraintController) READ_drafts() {}
+
+func (instance constraintController) MODIFY_posts_BY_id() {}
+
+func TestRESTGetConstraints(t *testing.T) {
+	controller := constraintController{}
+	rest := REST{}
+	rest.AddHandlerToRouterMap([]string{}, "READ_posts_BY_id", controller.READ_posts_BY_id)
+	rest.AddHandlerToRouterMap([]string{}, "READ_drafts", controller.READ_drafts)
+	rest.AddHandlerToRouterMap([]string{}, "MODIFY_posts_BY_id", controller.MODIFY_posts_BY_id)
+
+	rest.
+		Constraint("id", routing.IntConstraint).
+		Constraint("id", routing.UUIDConstraint, controller.MODIFY_posts_BY_id)
+
+	constraintItems := rest.GetConstraints()
+	if len(constraintItems) != 3 {
+		t.Fatalf(utils.ErrorMessage(len(constraintItems), 3, "constraint items should be equal"))
+	}
+
+	lastConstraintItem := constraintItems[2]
+	if lastConstraintItem.Method != "PATCH" || lastConstraintItem.Route != "/posts/{id}/" {
+		t.Errorf(utils.ErrorMessage(lastConstraintItem.Method+" "+lastConstraintItem.Route, "PATCH /posts/{id}/", "handler constraint should be set for bound handler"))
+	}
+
+	if lastConstraintItem.Constraint.Name != "uuid" {
+		t.Errorf(utils.ErrorMessage(lastConstraintItem.Constraint.Name, "uuid", "handler constraint should be equal"))
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf(utils.ErrorMessage(r, "panic", "constraint of undeclared param should panic"))
+		}
+	}()
+	rest.Constraint("id", routing.IntConstraint, controller.READ_drafts).GetConstraints()
+}
+
+type versionController struct{}
+
+func (instance versionController) READ_articles() {}
+
+func (instance versionController) READ_articles_BY_id() {}
+
+func (instance versionController) READ_articles_status() {}
+
+func TestRESTGetVersions(t *testing.T) {
+	controller := versionController{}
+	rest := REST{}
+	rest.
+		Version("1").
+		Version("2").
+		Version("3", controller.READ_articles_BY_id).
+		Version(VERSION_NEUTRAL, controller.READ_articles_status)
+
+	rest.AddHandlerToRouterMap([]string{}, "READ_articles", controller.READ_articles)
+	rest.AddHandlerToRouterMap([]string{}, "READ_articles_BY_id", controller.READ_articles_BY_id)
+	rest.AddHandlerToRouterMap([]string{}, "READ_articles_status", controller.READ_articles_status)
+
+	expectedPatterns := []string{
+		routing.AddMethodToRoute(routing.ToEndpoint(routing.VersionToRoute("1")+"/articles"), "GET"),
+		routing.AddMethodToRoute(routing.ToEndpoint(routing.VersionToRoute("2")+"/articles"), "GET"),
+		routing.AddMethodToRoute(routing.ToEndpoint(routing.VersionToRoute("3")+"/articles/{id}"), "GET"),
+		routing.AddMethodToRoute(routing.ToEndpoint("/articles_status"), "GET"),
+	}
+
+	if len(rest.PatternToFnNameMap) != len(expectedPatterns) {
+		t.Fatalf(utils.ErrorMessage(len(rest.PatternToFnNameMap), len(expectedPatterns), "versioned patterns should be equal"))
+	}
+
+	for _, pattern := range expectedPatterns {
+		if _, ok := rest.PatternToFnNameMap[pattern]; !ok {
+			t.Errorf(utils.ErrorMessage(rest.PatternToFnNameMap, pattern, "versioned pattern should be added"))
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf(utils.ErrorMessage(r, "panic", "invalid version should panic"))
+		}
+	}()
+	rest.Version("1/2")
+}
+
+func TestGetHandlerName(t *testing.T) {
+	controller := versionController{}
+	expected := "github.com/dangduoc08/gogo/common.versionController.READ_articles"
+
+	if actual := GetHandlerName(controller.READ_articles); actual != expected {
+		t.Errorf(utils.ErrorMessage(actual, expected, "handler name should be equal"))
+	}
+}
+
+type explicitController struct{}
+
+func (instance explicitController) FindProfile() {}
+
+func (instance explicitController) SaveProfile() {}
+
+func (instance explicitController) READ_profiles() {}
+
+func TestRESTExplicitRoutes(t *testing.T) {
+	controller := explicitController{}
+	rest := REST{}
+	rest.
+		Prefix("v1").
+		Get("/user-profiles/{id}", controller.FindProfile).
+		Any("user-profiles/{id}/settings", controller.SaveProfile)
+
+	rest.AddHandlerToRouterMap([]string{}, "FindProfile", controller.FindProfile)
+	rest.AddHandlerToRouterMap([]string{}, "SaveProfile", controller.SaveProfile)
+	rest.AddHandlerToRouterMap([]string{}, "READ_profiles", controller.READ_profiles)
+
+	expectedPatterns := map[string]string{
+		routing.AddMethodToRoute("/v1/user-profiles/{id}/", "GET"):             "FindProfile",
+		routing.AddMethodToRoute("/v1/user-profiles/{id}/settings/", "GET"):    "SaveProfile",
+		routing.AddMethodToRoute("/v1/user-profiles/{id}/settings/", "POST"):   "SaveProfile",
+		routing.AddMethodToRoute("/v1/user-profiles/{id}/settings/", "PUT"):    "SaveProfile",
+		routing.AddMethodToRoute("/v1/user-profiles/{id}/settings/", "PATCH"):  "SaveProfile",
+		routing.AddMethodToRoute("/v1/user-profiles/{id}/settings/", "DELETE"): "SaveProfile",
+		routing.AddMethodToRoute("/v1/profiles/", "GET"):                       "READ_profiles",
+	}
+
+	if len(rest.PatternToFnNameMap) != len(expectedPatterns) {
+		t.Fatalf(utils.ErrorMessage(len(rest.PatternToFnNameMap), len(expectedPatterns), "explicit patterns should be equal"))
+	}
+
+	for pattern, fnName := range expectedPatterns {
+		if rest.PatternToFnNameMap[pattern] != fnName {
+			t.Errorf(utils.ErrorMessage(rest.PatternToFnNameMap[pattern], fnName, "explicit pattern should be added"))
+		}
+	}
+
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf(utils.ErrorMessage(r, "panic", "conflicted explicit route should panic"))
+			}
+		}()
+		conflictedRest := REST{}
+		conflictedRest.Prefix("v1").Get("/profiles", controller.FindProfile)
+		conflictedRest.AddHandlerToRouterMap([]string{}, "FindProfile", controller.FindProfile)
+	}()
+
+	for _, path := range []string{"/files/{name}.json", "/a/v{ver}/x", "/a/{}/x", "/a/{b}{c}"} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf(utils.ErrorMessage(r, "panic", path+" partial param should panic"))
+				}
+			}()
+			rest.Get(path, controller.FindProfile)
+		}()
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf(utils.ErrorMessage(r, "panic", "non method handler should panic"))
+		}
+	}()
+	rest.Get("/profiles", func() {})
+}
+
+type operationController struct{}
+
+func (instance operationController) SEARCH_catalogs() {}
+
+func (instance operationController) READ_catalogs() {}
+
+func (instance operationController) LIST_catalogs() {}
+
+func TestAddRESTOperation(t *testing.T) {
+	operations := maps.Clone(RESTOperations)
+
+	if method := AddRESTOperation(operations, "SEARCH", "search"); method != "SEARCH" {
+		t.Errorf(utils.ErrorMessage(method, "SEARCH", "method should be normalized"))
+	}
+	AddRESTOperation(operations, "LIST", "GET")
+
+	method, route := ParseFnNameToURL("SEARCH_catalogs_BY_id", operations)
+	if method != "SEARCH" || route != "/catalogs/{id}/" {
+		t.Errorf(utils.ErrorMessage(method+" "+route, "SEARCH /catalogs/{id}/", "custom operation should be parsed"))
+	}
+
+	if _, ok := RESTOperations["SEARCH"]; ok {
+		t.Errorf(utils.ErrorMessage(RESTOperations, "built-in operations", "built-in operations should not be changed"))
+	}
+
+	if method, route := routing.SplitRoute(routing.AddMethodToRoute("/catalogs/", "SEARCH")); method != "SEARCH" || route != "/catalogs" {
+		t.Errorf(utils.ErrorMessage(method+" "+route, "SEARCH /catalogs", "extension method route should be split"))
+	}
+
+	for _, operation := range [][]string{{"BY", "GET"}, {"FIND_ALL", "GET"}, {"LIST", "POST"}, {"PURGE", "PUR GE"}} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf(utils.ErrorMessage(r, "panic", "invalid operation should panic"))
+				}
+			}()
+			AddRESTOperation(operations, operation[0], operation[1])
+		}()
+	}
+
+	controller := operationController{}
+	rest := REST{}
+	rest.Operations(operations)
+	rest.AddHandlerToRouterMap([]string{}, "SEARCH_catalogs", controller.SEARCH_catalogs)
+	rest.AddHandlerToRouterMap([]string{}, "READ_catalogs", controller.READ_catalogs)
+
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf(utils.ErrorMessage(r, "panic", "aliased operation should be conflicted"))
+			}
+		}()
+		rest.AddHandlerToRouterMap([]string{}, "LIST_catalogs", controller.LIST_catalogs)
+	}()
+}
@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
+	"net/url"
 	"os"
 	"path"
 	"reflect"
@@ -43,8 +45,15 @@ type App struct {
 	globalInterceptors                     []common.Interceptable
 	globalExceptionFilters                 []common.ExceptionFilterable
 	injectedProviders                      map[string]Provider
+	cookieSecrets                          []string
 	catchRESTFnsMap                        map[string][]common.Catch
 	catchWSFnsMap                          map[string][]common.Catch
+	versioning                             *VersioningOptions
+	namingStrategy                         common.NamingStrategy
+	restOperations                         map[string]string
+	restHandlerRoutes                      map[string][]string         // to build URLs, key = handler name
+	restMetadataMap                        map[string]*handlerMetadata // to reflect REST metadata, key = endpoint
+	wsMetadataMap                          map[string]*handlerMetadata // to reflect WS metadata, key = event name
 	Logger                                 common.Logger
 }
 
@@ -58,9 +67,12 @@ const (
 	FORM                = "github.com/dangduoc08/gogo/ctx/ctx.Form"
 	QUERY               = "github.com/dangduoc08/gogo/ctx/ctx.Query"
 	HEADER              = "github.com/dangduoc08/gogo/ctx/ctx.Header"
+	COOKIE              = "github.com/dangduoc08/gogo/ctx/ctx.Cookie"
 	PARAM               = "github.com/dangduoc08/gogo/ctx/ctx.Param"
 	FILE                = "github.com/dangduoc08/gogo/ctx/ctx.File"
 	WS_PAYLOAD          = "github.com/dangduoc08/gogo/ctx/ctx.WSPayload"
+	SSE_STREAM          = "/*ctx.SSEStream"
+	CSRF_TOKEN          = "github.com/dangduoc08/gogo/ctx/ctx.CSRFToken"
 	NEXT                = "/func()"
 	REDIRECT            = "/func(string)"
 	CONTEXT_PIPEABLE    = "context"
@@ -68,6 +80,7 @@ const (
 	FORM_PIPEABLE       = "form"
 	QUERY_PIPEABLE      = "query"
 	HEADER_PIPEABLE     = "header"
+	COOKIE_PIPEABLE     = "cookie"
 	PARAM_PIPEABLE      = "param"
 	FILE_PIPEABLE       = "file"
 	WS_PAYLOAD_PIPEABLE = "wsPayload"
@@ -82,9 +95,12 @@ var dependencies = map[string]int{
 	FORM:                1,
 	QUERY:               1,
 	HEADER:              1,
+	COOKIE:              1,
 	PARAM:               1,
 	FILE:                1,
 	WS_PAYLOAD:          1,
+	SSE_STREAM:          1,
+	CSRF_TOKEN:          1,
 	NEXT:                1,
 	REDIRECT:            1,
 	CONTEXT_PIPEABLE:    1,
@@ -92,6 +108,7 @@ var dependencies = map[string]int{
 	FORM_PIPEABLE:       1,
 	QUERY_PIPEABLE:      1,
 	HEADER_PIPEABLE:     1,
+	COOKIE_PIPEABLE:     1,
 	PARAM_PIPEABLE:      1,
 	FILE_PIPEABLE:       1,
 	WS_PAYLOAD_PIPEABLE: 1,
@@ -114,6 +131,9 @@ func New() *App {
 		wsEventMap:                             make(map[string][]func(*ctx.Context)),
 		wsMainHandlerMap:                       make(map[string]any),
 		serveStaticMapToLastWildcardSlashIndex: make(map[string]int),
+		restMetadataMap:                        make(map[string]*handlerMetadata),
+		wsMetadataMap:                          make(map[string]*handlerMetadata),
+		restOperations:                         maps.Clone(common.RESTOperations),
 		ctxPool: sync.Pool{
 			New: func() any {
 				c := ctx.NewContext()
@@ -135,6 +155,12 @@ func (app *App) Create(m *Module) {
 		app.Logger = log.NewLog(nil)
 	}
 	globalInterfaces[injectableInterfaces[0]] = app.Logger
+	globalProviders[genProviderKey(Reflector{})] = Reflector{
+		restMetadataMap: app.restMetadataMap,
+		wsMetadataMap:   app.wsMetadataMap,
+	}
+	m.namingStrategy = app.namingStrategy
+	m.restOperations = app.restOperations
 	app.module = m.NewModule()
 
 	var injectedProviders map[string]Provider = make(map[string]Provider)
@@ -152,11 +178,54 @@ func (app *App) Create(m *Module) {
 	// module interceptors (pre)
 	// main handler
 
+	// REST route param constraints
+	// must be registered before routes were added
+	for _, constraint := range app.module.RESTConstraints {
+		httpMethod := routing.ToHTTPMethod(constraint.Method)
+		app.route.Constrain(constraint.Route, httpMethod, constraint.Key, constraint.Constraint)
+	}
+
+	// REST routes by handler names
+	// to build URLs
+	app.restHandlerRoutes = make(map[string][]string)
+	for _, handlerRoute := range app.module.RESTHandlerRoutes {
+		if !utils.ArrIncludes(app.restHandlerRoutes[handlerRoute.Name], handlerRoute.Route) {
+			app.restHandlerRoutes[handlerRoute.Name] = append(app.restHandlerRoutes[handlerRoute.Name], handlerRoute.Route)
+		}
+	}
+
+	// handlers required by RequireURLs
+	// must be registered
+	for _, urlReference := range app.module.RESTURLReferences {
+		if len(app.restHandlerRoutes[urlReference.Name]) == 0 {
+			panic(fmt.Errorf(
+				utils.FmtRed(
+					"%v handler required by %v was not registered as REST handler",
+					urlReference.Name,
+					urlReference.ControllerName,
+				),
+			))
+		}
+	}
+
+	// REST handler metadata
+	for _, metadata := range app.module.RESTMetadata {
+		httpMethod := routing.ToHTTPMethod(metadata.Method)
+
+		endpoint := routing.ToEndpoint(routing.AddMethodToRoute(metadata.Route, httpMethod))
+		addMetadata(app.restMetadataMap, endpoint, metadata.Key, metadata.Value, metadata.IsHandler)
+	}
+
+	// WS handler metadata
+	for _, metadata := range app.module.WSMetadata {
+		addMetadata(app.wsMetadataMap, metadata.EventName, metadata.Key, metadata.Value, metadata.IsHandler)
+	}
+
 	// REST module exception filters
 	totalRESTModuleExceptionFilers := len(app.module.RESTExceptionFilters)
 	for i := totalRESTModuleExceptionFilers - 1; i >= 0; i-- {
 		moduleExceptionFilter := app.module.RESTExceptionFilters[i]
-		httpMethod := routing.OperationsMapHTTPMethods[moduleExceptionFilter.Method]
+		httpMethod := routing.ToHTTPMethod(moduleExceptionFilter.Method)
 
 		endpoint := routing.ToEndpoint(routing.AddMethodToRoute(moduleExceptionFilter.Route, httpMethod))
 		app.catchRESTFnsMap[endpoint] = append(app.catchRESTFnsMap[endpoint], moduleExceptionFilter.Handler.(common.Catch))
@@ -182,7 +251,7 @@ func (app *App) Create(m *Module) {
 
 		// REST global exception filters
 		for _, mainHandlerItem := range app.module.RESTMainHandlers {
-			httpMethod := routing.OperationsMapHTTPMethods[mainHandlerItem.Method]
+			httpMethod := routing.ToHTTPMethod(mainHandlerItem.Method)
 
 			endpoint := routing.ToEndpoint(routing.AddMethodToRoute(mainHandlerItem.Route, httpMethod))
 			app.catchRESTFnsMap[endpoint] = append(app.catchRESTFnsMap[endpoint], globalExceptionFilter.Catch)
@@ -251,7 +320,7 @@ func (app *App) Create(m *Module) {
 
 		// add catch middleware
 		method, route := routing.SplitRoute(pattern)
-		httpMethod := routing.OperationsMapHTTPMethods[method]
+		httpMethod := routing.ToHTTPMethod(method)
 
 		app.route.For(route, []string{httpMethod})(catchMiddleware)
 	}
@@ -318,8 +387,8 @@ func (app *App) Create(m *Module) {
 	// global middlewares
 	for _, globalMiddleware := range app.globalMiddlewares {
 		if globalMiddleware.route != "*" {
-			httpMethods := utils.ArrMap(routing.HTTPMethods, func(el string, i int) string {
-				return routing.OperationsMapHTTPMethods[el]
+			httpMethods := utils.ArrMap(app.route.Methods(), func(el string, i int) string {
+				return routing.ToHTTPMethod(el)
 			})
 
 			app.route.For(globalMiddleware.route, httpMethods)(globalMiddleware.handler)
@@ -340,7 +409,7 @@ func (app *App) Create(m *Module) {
 
 	// REST module middlewares
 	for _, restModuleMiddleware := range app.module.RESTMiddlewares {
-		httpMethod := routing.OperationsMapHTTPMethods[restModuleMiddleware.Method]
+		httpMethod := routing.ToHTTPMethod(restModuleMiddleware.Method)
 
 		app.route.For(restModuleMiddleware.Route, []string{httpMethod})(restModuleMiddleware.Handlers...)
 	}
@@ -370,7 +439,7 @@ func (app *App) Create(m *Module) {
 
 		// REST global guards
 		for _, mainHandlerItem := range app.module.RESTMainHandlers {
-			httpMethod := routing.OperationsMapHTTPMethods[mainHandlerItem.Method]
+			httpMethod := routing.ToHTTPMethod(mainHandlerItem.Method)
 
 			app.route.For(mainHandlerItem.Route, []string{httpMethod})(canActivateMiddleware)
 		}
@@ -393,7 +462,7 @@ func (app *App) Create(m *Module) {
 			}
 		}(moduleGuard.Handler.(common.CanActivate))
 
-		httpMethod := routing.OperationsMapHTTPMethods[moduleGuard.Method]
+		httpMethod := routing.ToHTTPMethod(moduleGuard.Method)
 		app.route.For(moduleGuard.Route, []string{httpMethod})(canActivateMiddleware)
 	}
 
@@ -423,7 +492,7 @@ func (app *App) Create(m *Module) {
 
 		// REST global interceptors
 		for _, mainHandlerItem := range app.module.RESTMainHandlers {
-			httpMethod := routing.OperationsMapHTTPMethods[mainHandlerItem.Method]
+			httpMethod := routing.ToHTTPMethod(mainHandlerItem.Method)
 			endpoint := routing.ToEndpoint(routing.AddMethodToRoute(mainHandlerItem.Route, httpMethod))
 
 			interceptMiddleware := func(interceptor common.Interceptable) ctx.Handler {
@@ -500,7 +569,7 @@ func (app *App) Create(m *Module) {
 
 	// REST module interceptors
 	for _, moduleInterceptor := range app.module.RESTInterceptors {
-		httpMethod := routing.OperationsMapHTTPMethods[moduleInterceptor.Method]
+		httpMethod := routing.ToHTTPMethod(moduleInterceptor.Method)
 		endpoint := routing.ToEndpoint(routing.AddMethodToRoute(moduleInterceptor.Route, httpMethod))
 
 		interceptMiddleware := func(interceptFn common.Intercept) ctx.Handler {
@@ -577,7 +646,7 @@ func (app *App) Create(m *Module) {
 
 	// main REST handler
 	for _, moduleHandler := range app.module.RESTMainHandlers {
-		httpMethod := routing.OperationsMapHTTPMethods[moduleHandler.Method]
+		httpMethod := routing.ToHTTPMethod(moduleHandler.Method)
 		if moduleHandler.Method == routing.SERVE {
 			r := moduleHandler.Route
 			lr := len(r)
@@ -627,6 +696,50 @@ func (app *App) Use(handlers ...ctx.Handler) *App {
 	return app
 }
 
+// UseRouterOptions sets policies of
+// trailing slash, dot segments, letter case
+// and encoded path matching
+func (app *App) UseRouterOptions(opts routing.RouterOptions) *App {
+	app.route.SetOptions(opts)
+
+	return app
+}
+
+// AddRESTOperation maps operation word of function names
+// to HTTP method e.g. app.AddRESTOperation("SEARCH", "SEARCH"),
+// it panics if called after Create
+func (app *App) AddRESTOperation(operation, method string) *App {
+	if app.module != nil {
+		panic(fmt.Errorf(
+			utils.FmtRed(
+				"%v operation must be added before Create",
+				operation,
+			),
+		))
+	}
+	app.route.AddHTTPMethod(common.AddRESTOperation(app.restOperations, operation, method))
+
+	return app
+}
+
+// UseNamingStrategy converts static segments
+// of function name routes e.g. common.KebabCase,
+// it must be called before Create
+// and is overridden by controller naming strategy
+func (app *App) UseNamingStrategy(namingStrategy common.NamingStrategy) *App {
+	app.namingStrategy = namingStrategy
+
+	return app
+}
+
+// UseCookieSecrets sets secrets of signed and encrypted cookies,
+// prepend new secret to rotate
+func (app *App) UseCookieSecrets(secrets ...string) *App {
+	app.cookieSecrets = secrets
+
+	return app
+}
+
 func (app *App) UseLogger(logger common.Logger) *App {
 	app.Logger = logger
 	globalInterfaces[injectableInterfaces[0]] = app.Logger
@@ -660,6 +773,8 @@ func (app *App) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	c.Timestamp = time.Now()
 	c.ResponseWriter = w
 	c.Request = r
+	c.SetCookieSecrets(app.cookieSecrets)
+	c.SetURLBuilder(app.URLFor)
 	ctxID := app.getContextID(c)
 	c.SetID(ctxID)
 
@@ -693,14 +808,22 @@ func (app *App) Listen(port int) error {
 
 	for _, routName := range routeArr {
 		m, r := routing.SplitRoute(routName)
+		h, r := routing.SplitHost(r)
+		v, r := routing.SplitVersion(r)
 		if r == "" {
 			r = "/"
 		}
-		app.Logger.Info(
-			"RouteExplorer",
-			"method", m,
-			"route", r,
-		)
+
+		args := []any{"method", m}
+		if h != "" {
+			args = append(args, "host", h)
+		}
+		if v != "" {
+			args = append(args, "version", v)
+		}
+		args = append(args, "route", r)
+
+		app.Logger.Info("RouteExplorer", args...)
 	}
 
 	// WS logs
@@ -745,6 +868,11 @@ func (app *App) handleRESTRequest(c *ctx.Context) {
 
 				// 3rd param is index of catch function
 				c.Event.Emit(catchEvent, c, rec, 0)
+			} else {
+
+				// unmatched routes have no exception filters,
+				// e.g. global middlewares rejected request
+				app.returnUncaughtException(c, rec)
 			}
 		}
 	}()
@@ -754,11 +882,53 @@ func (app *App) handleRESTRequest(c *ctx.Context) {
 		isNext = true
 	}
 
-	isMatched, matchedRoute, paramKeys, paramValues, handlers := app.route.Match(c.Request.URL.Path, c.Request.Method)
+	requestedPath := c.Request.URL.Path
+	if app.route.Options.IsEncodedPath {
+		requestedPath = c.Request.URL.EscapedPath()
+	}
+
+	if canonicalPath, policy := app.route.Canonicalize(requestedPath); policy != routing.PathLenient {
+
+		// global middlewares e.g. CORS and logger
+		// apply to non canonical paths as well
+		for _, middleware := range app.route.GlobalMiddlewares {
+			if isNext {
+				isNext = false
+				middleware(c)
+			}
+		}
+
+		if isNext {
+			switch policy {
+			case routing.PathReject:
+				app.returnInvalidURL(c)
+			case routing.PathStrict:
+				app.returnNotFound(c)
+			case routing.PathRedirect:
+				app.redirectToCanonicalPath(c, canonicalPath)
+			}
+		}
+
+		return
+	}
+
+	isMatched, matchedRoute, paramKeys, paramValues, handlers := app.matchVersion(c, requestedPath, c.Request.Method)
+
+	// HEAD requests are handled by GET handlers
+	// without response body
+	if !isMatched && c.Request.Method == http.MethodHead {
+		isMatched, matchedRoute, paramKeys, paramValues, handlers = app.matchVersion(c, requestedPath, http.MethodGet)
+		if isMatched {
+			c.ResponseWriter = &headResponseWriter{
+				ResponseWriter: c.ResponseWriter,
+			}
+		}
+	}
 	catchEvent = matchedRoute
 
 	if isMatched {
 		c.SetRoute(matchedRoute)
+		app.setDeprecationHeaders(c, matchedRoute)
 		c.ParamKeys = paramKeys
 		c.ParamValues = paramValues
 		if c.Request.Method == http.MethodPost {
@@ -774,70 +944,76 @@ func (app *App) handleRESTRequest(c *ctx.Context) {
 					// meaning this is injectable handler
 					injectableHandler := app.route.InjectableHandlers[matchedRoute]
 
+					aggregations, isIntercepted := c.Request.Context().Value(WithValueKey(matchedRoute)).([]*aggregation.Aggregation)
+					lastWildcardSlashIndex, isServeStatic := app.serveStaticMapToLastWildcardSlashIndex[matchedRoute]
+
+					// outermost interceptor which responded without Pipe
+					shortCircuitedIndex := -1
+					for i, aggregation := range aggregations {
+						if !aggregation.IsMainHandlerCalled {
+							shortCircuitedIndex = i
+							break
+						}
+					}
+
 					// data return from main handler
-					data := app.provideAndInvoke(injectableHandler, c)
+					// main handler is skipped
+					// when interceptor responded without Pipe
+					var data []reflect.Value
+					if isServeStatic || shortCircuitedIndex == -1 {
+						data = app.provideAndInvoke(injectableHandler, c)
+					}
 
-					if aggregations, ok := c.Request.Context().Value(WithValueKey(matchedRoute)).([]*aggregation.Aggregation); ok {
+					if isIntercepted {
 						var aggregatedData any
-						isMainHandlerCalled := true
-
 						totalAggregations := len(aggregations)
 
 						for i := totalAggregations - 1; i >= 0; i-- {
 							aggregation := aggregations[i]
 
-							if aggregation.IsMainHandlerCalled {
-
-								// set data from main handler into
-								// first interceptor
-								if i == totalAggregations-1 {
-									if len(data) == 1 {
-										aggregatedData = data[0].Interface()
-									} else if len(data) > 1 {
-										setStatusCode(c, data[0])
-										aggregatedData = data[1].Interface()
-									}
-								}
-
-								aggregation.SetMainData(aggregatedData)
-								aggregatedData = aggregation.Aggregate(c)
-							} else {
-								isMainHandlerCalled = false
-								if lastWildcardSlashIndex, ok := app.serveStaticMapToLastWildcardSlashIndex[matchedRoute]; ok {
-									var dir any
-
-									if len(data) == 1 {
-										dir = data[0].Interface()
-									} else if len(data) > 1 {
-										setStatusCode(c, data[0])
-										dir = data[1].Interface()
-									}
-									app.serveContent(c, lastWildcardSlashIndex, dir)
-								} else {
-									returnREST(c, reflect.ValueOf(aggregation.InterceptorData))
-								}
-								break
+							// data of inner interceptors are discarded
+							if shortCircuitedIndex > -1 && i > shortCircuitedIndex {
+								continue
 							}
-						}
 
-						if isMainHandlerCalled {
-							if lastWildcardSlashIndex, ok := app.serveStaticMapToLastWildcardSlashIndex[matchedRoute]; ok {
-								var dir any
+							// interceptor data replaces main handler data
+							// and continues aggregating by outer interceptors
+							if i == shortCircuitedIndex {
+								aggregatedData = aggregation.InterceptorData
+								continue
+							}
 
+							// set data from main handler into
+							// first interceptor
+							if i == totalAggregations-1 {
 								if len(data) == 1 {
-									dir = data[0].Interface()
+									aggregatedData = data[0].Interface()
 								} else if len(data) > 1 {
 									setStatusCode(c, data[0])
-									dir = data[1].Interface()
+									aggregatedData = data[1].Interface()
 								}
-								app.serveContent(c, lastWildcardSlashIndex, dir)
-							} else {
-								returnREST(c, reflect.ValueOf(aggregatedData))
 							}
+
+							aggregation.SetMainData(aggregatedData)
+							aggregatedData = aggregation.Aggregate(c)
+						}
+
+						if isServeStatic {
+							var dir any
+
+							if len(data) == 1 {
+								dir = data[0].Interface()
+							} else if len(data) > 1 {
+								setStatusCode(c, data[0])
+								dir = data[1].Interface()
+							}
+							app.serveContent(c, lastWildcardSlashIndex, dir)
+						} else {
+							returnREST(c, reflect.ValueOf(aggregatedData))
 						}
 					} else {
 						if len(data) == 1 {
-							if lastWildcardSlashIndex, ok := app.serveStaticMapToLastWildcardSlashIndex[matchedRoute]; ok {
+							if isServeStatic {
 								dir := data[0].Interface()
 								app.serveContent(c, lastWildcardSlashIndex, dir)
 							} else {
@@ -845,7 +1021,7 @@ func (app *App) handleRESTRequest(c *ctx.Context) {
 							}
 						} else if len(data) > 1 {
 							setStatusCode(c, data[0])
-							if lastWildcardSlashIndex, ok := app.serveStaticMapToLastWildcardSlashIndex[matchedRoute]; ok {
+							if isServeStatic {
 								dir := data[1].Interface()
 								app.serveContent(c, lastWildcardSlashIndex, dir)
 							} else {
@@ -868,7 +1044,22 @@ func (app *App) handleRESTRequest(c *ctx.Context) {
 		}
 
 		if isNext {
-			app.returnNotFound(c)
+
+			// path exists
+			// but method doesn't
+			if allowedMethods := app.allowedVersionMethods(c, requestedPath); len(allowedMethods) > 0 {
+				c.ResponseWriter.Header().Set("Allow", getAllowHeader(allowedMethods, app.route.Methods()))
+
+				if c.Request.Method == http.MethodOptions {
+					c.Status(http.StatusNoContent)
+					c.ResponseWriter.WriteHeader(c.Code)
+					c.Event.Emit(ctx.REQUEST_FINISHED, c)
+				} else {
+					app.returnMethodNotAllowed(c)
+				}
+			} else {
+				app.returnNotFound(c)
+			}
 		}
 	}
 }
@@ -1137,6 +1328,47 @@ func (app *App) returnNotFound(c *ctx.Context) {
 	})
 }
 
+func (app *App) returnUncaughtException(c *ctx.Context, rec any) {
+	httpException, ok := rec.(exception.HTTPException)
+	if !ok {
+		httpException = exception.InternalServerErrorException("Unhandled exception has occurred")
+	}
+
+	globalExceptionFilter{}.Catch(c, &httpException)
+}
+
+func (app *App) returnMethodNotAllowed(c *ctx.Context) {
+	methodNotAllowedException := exception.MethodNotAllowedException(fmt.Sprintf("Cannot %v %v", c.Method, c.URL.Path))
+	httpCode, _ := methodNotAllowedException.GetHTTPStatus()
+	c.Status(httpCode)
+	c.JSON(ctx.Map{
+		"code":    methodNotAllowedException.GetCode(),
+		"error":   methodNotAllowedException.Error(),
+		"message": methodNotAllowedException.GetResponse(),
+	})
+}
+
+// redirectToCanonicalPath keeps method and body
+// of non GET requests by 308
+func (app *App) redirectToCanonicalPath(c *ctx.Context, canonicalPath string) {
+	redirectedURL := *c.Request.URL
+	if app.route.Options.IsEncodedPath {
+		redirectedURL.Path, _ = url.PathUnescape(canonicalPath)
+		redirectedURL.RawPath = canonicalPath
+	} else {
+		redirectedURL.Path = canonicalPath
+		redirectedURL.RawPath = ""
+	}
+
+	c.Status(http.StatusPermanentRedirect)
+	if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+		c.Status(http.StatusMovedPermanently)
+	}
+
+	http.Redirect(c.ResponseWriter, c.Request, redirectedURL.RequestURI(), c.Code)
+	c.Event.Emit(ctx.REQUEST_FINISHED, c)
+}
+
 func (app *App) returnInvalidURL(c *ctx.Context) {
 	badRequestException := exception.BadRequestException("Invalid URL path")
 	httpCode, _ := badRequestException.GetHTTPStatus()
@@ -0,0 +1,158 @@
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dangduoc08/gogo/common"
+	"github.com/dangduoc08/gogo/ctx"
+	"github.com/dangduoc08/gogo/routing"
+	"github.com/dangduoc08/gogo/utils"
+)
+
+type kebabNamingController struct {
+	common.REST
+}
+
+func (instance kebabNamingController) NewController() Controller {
+	return instance
+}
+
+func (instance kebabNamingController) READ_kebab_naming_profiles(c *ctx.Context) string {
+	return "kebab"
+}
+
+type defaultNamingController struct {
+	common.REST
+}
+
+func (instance defaultNamingController) NewController() Controller {
+	return instance
+}
+
+func (instance defaultNamingController) READ_default_naming_profiles(c *ctx.Context) string {
+	return "default"
+}
+
+func TestUseNamingStrategy(t *testing.T) {
+	kebabApp := New().UseNamingStrategy(common.KebabCase)
+	mainModulePtr = 0
+	modulesInjectedFromMain = nil
+	kebabApp.Create(ModuleBuilder().Controllers(kebabNamingController{}).Build())
+
+	// naming strategy belongs to App
+	// which it was set on
+	defaultApp := createTestApp(ModuleBuilder().Controllers(defaultNamingController{}).Build())
+
+	cases := []struct {
+		app      *App
+		path     string
+		expected string
+	}{
+		{kebabApp, "/kebab-naming-profiles", "kebab"},
+		{defaultApp, "/default_naming_profiles", "default"},
+	}
+
+	for _, testCase := range cases {
+		w := httptest.NewRecorder()
+		testCase.app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, testCase.path, nil))
+
+		if w.Code != http.StatusOK || w.Body.String() != testCase.expected {
+			t.Errorf(utils.ErrorMessage(w.Body.String(), testCase.expected, testCase.path+" should be named by strategy of its App"))
+		}
+	}
+}
+
+type operationCatalogController struct {
+	common.REST
+}
+
+func (instance operationCatalogController) NewController() Controller {
+	return instance
+}
+
+func (instance operationCatalogController) SEARCH_operation_catalogs(c *ctx.Context) string {
+	return "search"
+}
+
+func TestAddRESTOperation(t *testing.T) {
+	searchApp := New().AddRESTOperation("SEARCH", "SEARCH")
+	mainModulePtr = 0
+	modulesInjectedFromMain = nil
+	searchApp.Create(ModuleBuilder().Controllers(operationCatalogController{}).Build())
+
+	w := httptest.NewRecorder()
+	searchApp.ServeHTTP(w, httptest.NewRequest("SEARCH", "/operation_catalogs", nil))
+	if w.Body.String() != "search" {
+		t.Errorf(utils.ErrorMessage(w.Body.String(), "search", "custom operation should be routed"))
+	}
+
+	w = httptest.NewRecorder()
+	searchApp.ServeHTTP(w, httptest.NewRequest(http.MethodOptions, "/operation_catalogs", nil))
+	if allow := w.Header().Get("Allow"); allow != "OPTIONS, SEARCH" {
+		t.Errorf(utils.ErrorMessage(allow, "OPTIONS, SEARCH", "custom method should be allowed"))
+	}
+
+	// operations belong to App
+	// which they were added to
+	if _, ok := common.RESTOperations["SEARCH"]; ok {
+		t.Errorf(utils.ErrorMessage(common.RESTOperations, "built-in operations", "built-in operations should not be changed"))
+	}
+
+	func() {
+		defer func() {
+			if rec := recover(); rec == nil {
+				t.Errorf(utils.ErrorMessage(rec, "panic", "operation added after Create should panic"))
+			}
+		}()
+		searchApp.AddRESTOperation("PURGE", "PURGE")
+	}()
+}
+
+type canonicalPathController struct {
+	common.REST
+}
+
+func (instance canonicalPathController) NewController() Controller {
+	return instance
+}
+
+func (instance canonicalPathController) READ_canonical_paths(c *ctx.Context) string {
+	return "canonical"
+}
+
+func TestCanonicalPathGlobalMiddlewares(t *testing.T) {
+	mainModulePtr = 0
+	modulesInjectedFromMain = nil
+
+	app := New().UseRouterOptions(routing.RouterOptions{
+		TrailingSlash: routing.PathRedirect,
+		DotSegments:   routing.PathReject,
+	})
+	app.Use(func(c *ctx.Context) {
+		c.ResponseWriter.Header().Set("Access-Control-Allow-Origin", "*")
+		c.Next()
+	})
+	app.Create(ModuleBuilder().Controllers(canonicalPathController{}).Build())
+
+	cases := map[string]int{
+		"/canonical_paths/":           http.StatusMovedPermanently,
+		"/canonical_paths/../secrets": http.StatusBadRequest,
+	}
+
+	for path, expected := range cases {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.URL.Path = path
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, r)
+
+		if w.Code != expected {
+			t.Errorf(utils.ErrorMessage(w.Code, expected, path+" should be answered by canonical path policy"))
+		}
+
+		if allowOrigin := w.Header().Get("Access-Control-Allow-Origin"); allowOrigin != "*" {
+			t.Errorf(utils.ErrorMessage(allowOrigin, "*", path+" should pass global middlewares"))
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package core
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dangduoc08/gogo/ctx"
+	"github.com/dangduoc08/gogo/exception"
+	"github.com/dangduoc08/gogo/utils"
+)
+
+func TestUncaughtExceptionOfUnmatchedRoute(t *testing.T) {
+	cases := map[string]struct {
+		rec      any
+		expected int
+	}{
+		"/uncaught-forbidden": {exception.ForbiddenException("Rejected by global middleware"), http.StatusForbidden},
+		"/uncaught-errors":    {errors.New("unexpected"), http.StatusInternalServerError},
+	}
+
+	mainModulePtr = 0
+	modulesInjectedFromMain = nil
+
+	// main module of later apps
+	// must not be this one
+	defer func() {
+		mainModulePtr = 0
+		modulesInjectedFromMain = nil
+	}()
+
+	app := New()
+	app.Use(func(c *ctx.Context) {
+		panic(cases[c.Request.URL.Path].rec)
+	})
+	app.Create(ModuleBuilder().Build())
+
+	for path, testCase := range cases {
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, nil))
+
+		if w.Code != testCase.expected {
+			t.Errorf(utils.ErrorMessage(w.Code, testCase.expected, "panic of unmatched route should be answered by global exception filter"))
+		}
+
+		if contentType := w.Header().Get("Content-Type"); contentType == "" {
+			t.Errorf(utils.ErrorMessage(contentType, "application/json", "exception should be responded as JSON"))
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"go/token"
+	"io"
 	"net"
 	"net/http"
 	"os"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/dangduoc08/gogo/common"
 	"github.com/dangduoc08/gogo/ctx"
+	"github.com/dangduoc08/gogo/exception"
 	"github.com/dangduoc08/gogo/utils"
 )
 
@@ -69,6 +71,13 @@ func getFnArgs(f any, injectedProviders map[string]Provider, cb func(string, int
 			}
 
 			cb(HEADER_PIPEABLE, i, newArg)
+		} else if cookiePipeable, isImplCookiePipeable := argAnyValue.(common.CookiePipeable); isImplCookiePipeable {
+			newArg, err := injectDependencies(cookiePipeable, "pipe", injectedProviders)
+			if err != nil {
+				panic(err)
+			}
+
+			cb(COOKIE_PIPEABLE, i, newArg)
 		} else if paramPipeable, isImplParamPipeable := argAnyValue.(common.ParamPipeable); isImplParamPipeable {
 			newArg, err := injectDependencies(paramPipeable, "pipe", injectedProviders)
 			if err != nil {
@@ -239,6 +248,16 @@ func injectDependencies(component any, kind string, dependencies map[string]Prov
 	return newComponent, nil
 }
 
+// genHandlerName generates name of controller method
+// which is equal to common.GetHandlerName of method value
+func genHandlerName(controllerType reflect.Type, methodName string) string {
+	if controllerType.Kind() == reflect.Pointer {
+		return controllerType.Elem().PkgPath() + ".(*" + controllerType.Elem().Name() + ")." + methodName
+	}
+
+	return controllerType.PkgPath() + "." + controllerType.Name() + "." + methodName
+}
+
 func getLocalIP() string {
 	addrs, err := net.InterfaceAddrs()
 	if err != nil {
@@ -288,12 +307,18 @@ func getDependency(k string, c *ctx.Context, pipeValue reflect.Value) any {
 		return c.Query()
 	case HEADER:
 		return c.Header()
+	case COOKIE:
+		return c.Cookies()
 	case PARAM:
 		return c.Param()
 	case FILE:
 		return c.File()
 	case WS_PAYLOAD:
 		return c.WS.Message.Payload
+	case SSE_STREAM:
+		return c.SSE()
+	case CSRF_TOKEN:
+		return ctx.CSRFToken(c.GetCSRFToken())
 	case NEXT:
 		return c.Next
 	case REDIRECT:
@@ -333,6 +358,13 @@ func getDependency(k string, c *ctx.Context, pipeValue reflect.Value) any {
 				ParamType:   HEADER_PIPEABLE,
 				ContextType: c.GetType(),
 			})
+	case COOKIE_PIPEABLE:
+		return pipeValue.
+			Interface().(common.CookiePipeable).
+			Transform(c.Cookies(), common.ArgumentMetadata{
+				ParamType:   COOKIE_PIPEABLE,
+				ContextType: c.GetType(),
+			})
 	case PARAM_PIPEABLE:
 		return pipeValue.
 			Interface().(common.ParamPipeable).
@@ -360,7 +392,41 @@ func getDependency(k string, c *ctx.Context, pipeValue reflect.Value) any {
 }
 
 func returnREST(c *ctx.Context, data reflect.Value) {
+
+	// handler returned nothing
+	// e.g streamed via injected SSEStream
+	if !data.IsValid() {
+		return
+	}
+
+	// streamable values
+	// have higher priority than kinds
+	switch streamData := data.Interface().(type) {
+	case ctx.Download:
+		c.Download(streamData)
+		return
+	case *ctx.Download:
+		if streamData == nil {
+			panic(exception.NotFoundException("File not found"))
+		}
+		c.Download(*streamData)
+		return
+	case []byte:
+		c.Bytes(streamData)
+		return
+	case io.Reader:
+		c.Stream(streamData)
+		return
+	}
+
 	switch data.Type().Kind() {
+	case
+		reflect.Chan:
+		if sseEvents, ok := toSSEEvents(data); ok {
+			c.SSE().Pipe(sseEvents)
+		} else {
+			c.Text(data.Type().String())
+		}
 	case
 		reflect.Map,
 		reflect.Slice,
@@ -397,6 +463,17 @@ func returnREST(c *ctx.Context, data reflect.Value) {
 	}
 }
 
+func toSSEEvents(data reflect.Value) (<-chan ctx.SSEEvent, bool) {
+	switch sseEvents := data.Interface().(type) {
+	case <-chan ctx.SSEEvent:
+		return sseEvents, true
+	case chan ctx.SSEEvent:
+		return sseEvents, true
+	}
+
+	return nil, false
+}
+
 func toWSMessage(data reflect.Value) string {
 	switch data.Type().Kind() {
 	case
@@ -467,3 +544,40 @@ func toUniqueControllers(module *Module, controllers *[]Controller) {
 
 	*controllers = uniqueControllers
 }
+
+// getAllowHeader adds methods
+// which are answered automatically
+func getAllowHeader(allowedMethods, routerMethods []string) string {
+	isAllowed := make(map[string]bool)
+	for _, method := range allowedMethods {
+		isAllowed[method] = true
+	}
+
+	if isAllowed[http.MethodGet] {
+		isAllowed[http.MethodHead] = true
+	}
+	isAllowed[http.MethodOptions] = true
+
+	methods := []string{}
+	for _, method := range routerMethods {
+		if isAllowed[method] {
+			methods = append(methods, method)
+		}
+	}
+
+	return strings.Join(methods, ", ")
+}
+
+// headResponseWriter discards body
+// written by GET handlers for HEAD requests
+type headResponseWriter struct {
+	http.ResponseWriter
+}
+
+func (w *headResponseWriter) Write(b []byte) (int, error) {
+	return len(b), nil
+}
+
+func (w *headResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
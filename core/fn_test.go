@@ -0,0 +1,22 @@
+package core
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/dangduoc08/gogo/ctx"
+	"github.com/dangduoc08/gogo/exception"
+	"github.com/dangduoc08/gogo/utils"
+)
+
+func TestReturnRESTNilDownload(t *testing.T) {
+	defer func() {
+		httpException, ok := recover().(exception.HTTPException)
+		if code, _ := httpException.GetHTTPStatus(); !ok || code != http.StatusNotFound {
+			t.Errorf(utils.ErrorMessage(code, http.StatusNotFound, "nil download should raise NotFoundException"))
+		}
+	}()
+
+	returnREST(ctx.NewContext(), reflect.ValueOf((*ctx.Download)(nil)))
+}
@@ -49,6 +49,7 @@ func (mw *Middleware) Apply(middleware ctx.Handler, handlers ...any) *Middleware
 
 func (mw *Middleware) addREST(
 	controllerName string,
+	restOperations map[string]string,
 	restMiddlewares *[]struct {
 		controllerName string
 		Method         string
@@ -72,7 +73,11 @@ func (mw *Middleware) addREST(
 				Handlers:       middlewareHandlers,
 			}
 			*restMiddlewares = append(*restMiddlewares, middlewareStruct)
-		} else if httpMethod, _ := common.ParseFnNameToURL(key, common.RESTOperations); httpMethod != "" {
+		} else {
+
+			// explicitly routed handlers have no operation,
+			// keys are bound to routes by handler names
+			httpMethod, _ := common.ParseFnNameToURL(key, restOperations)
 			middlewareStruct := struct {
 				controllerName string
 				Method         string
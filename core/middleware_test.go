@@ -0,0 +1,71 @@
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dangduoc08/gogo/common"
+	"github.com/dangduoc08/gogo/ctx"
+	"github.com/dangduoc08/gogo/utils"
+)
+
+// createTestApp creates app of m,
+// main module is reset
+// since controllers are only injected by first created app
+func createTestApp(m *Module) *App {
+	mainModulePtr = 0
+	modulesInjectedFromMain = nil
+
+	app := New()
+	app.Create(m)
+
+	return app
+}
+
+type explicitMiddlewareController struct {
+	common.REST
+}
+
+func (instance explicitMiddlewareController) NewController() Controller {
+	instance.Get("/middleware-profiles/{id}", instance.FindProfile)
+
+	return instance
+}
+
+func (instance explicitMiddlewareController) FindProfile(c *ctx.Context) string {
+	return c.ResponseWriter.Header().Get("X-Middleware")
+}
+
+func (instance explicitMiddlewareController) READ_middleware_settings(c *ctx.Context) string {
+	return c.ResponseWriter.Header().Get("X-Middleware")
+}
+
+func TestMiddlewareApplyExplicitRoute(t *testing.T) {
+	controller := explicitMiddlewareController{}
+	module := ModuleBuilder().Controllers(controller).Build()
+	module.Middleware.Apply(func(c *ctx.Context) {
+		c.ResponseWriter.Header().Set("X-Middleware", "applied")
+		c.Next()
+	}, controller.FindProfile)
+
+	app := createTestApp(ModuleBuilder().Imports(module).Build())
+
+	cases := map[string]string{
+		"/middleware-profiles/1": "applied",
+		"/middleware_settings":   "",
+	}
+
+	for path, expected := range cases {
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+		if w.Code != http.StatusOK {
+			t.Fatalf(utils.ErrorMessage(w.Code, http.StatusOK, path+" should be matched"))
+		}
+
+		if actual := w.Header().Get("X-Middleware"); actual != expected {
+			t.Errorf(utils.ErrorMessage(actual, expected, path+" middleware should be bound by handler"))
+		}
+	}
+}
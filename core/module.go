@@ -16,6 +16,7 @@ var mainModulePtr uintptr
 var modulesInjectedFromMain []uintptr
 var injectedDynamicModules = make(map[uintptr]*Module)
 var globalPrefixes = map[string][]string{}
+var globalHosts = map[string]string{}
 var globalProviders map[string]Provider = make(map[string]Provider)
 var globalInterfaces map[string]any = make(map[string]any)
 var providerInjectCheck map[string]Provider = make(map[string]Provider)
@@ -30,6 +31,8 @@ var noInjectedFields = []string{
 	"common.ExceptionFilter",
 	"WS",
 	"common.WS",
+	"Metadata",
+	"common.Metadata",
 }
 var injectableInterfaces = []string{
 	"github.com/dangduoc08/gogo/common/common.Logger",
@@ -38,6 +41,12 @@ var injectableInterfaces = []string{
 type Module struct {
 	id       string
 	prefixes []string
+	host     string
+
+	// naming strategy and REST operations of App,
+	// passed down to injected modules
+	namingStrategy common.NamingStrategy
+	restOperations map[string]string
 
 	*sync.Mutex
 	singleInstance *Module
@@ -79,6 +88,23 @@ type Module struct {
 		Handler any
 	}
 
+	// store REST handler metadata
+	RESTMetadata []struct {
+		Method    string
+		Route     string
+		Key       string
+		Value     any
+		IsHandler bool
+	}
+
+	// store REST route param constraints
+	RESTConstraints []struct {
+		Method     string
+		Route      string
+		Key        string
+		Constraint routing.Constraint
+	}
+
 	// store REST main handlers
 	RESTMainHandlers []struct {
 		Method  string
@@ -86,6 +112,19 @@ type Module struct {
 		Handler any
 	}
 
+	// store REST routes by handler names
+	RESTHandlerRoutes []struct {
+		Name   string
+		Method string
+		Route  string
+	}
+
+	// store handlers which URLs are required
+	RESTURLReferences []struct {
+		ControllerName string
+		Name           string
+	}
+
 	// store WS module middlewares
 	WSMiddlewares []struct {
 		controllerName string
@@ -115,6 +154,15 @@ type Module struct {
 		Handler     any
 	}
 
+	// store WS handler metadata
+	WSMetadata []struct {
+		Subprotocol string
+		EventName   string
+		Key         string
+		Value       any
+		IsHandler   bool
+	}
+
 	// store WS main handlers
 	WSMainHandlers []struct {
 		Subprotocol string
@@ -137,6 +185,15 @@ func (m *Module) Prefix(prefix string) *Module {
 	return m
 }
 
+// Host binds controllers of module to host pattern
+// e.g. {tenant}.example.com,
+// host bound by controller takes precedence
+func (m *Module) Host(host string) *Module {
+	m.host = host
+
+	return m
+}
+
 func (m *Module) ID() string {
 	return m.id
 }
@@ -200,6 +257,10 @@ func (m *Module) NewModule() *Module {
 			// to make it injectable
 
 			// recursion injection
+			staticModule.namingStrategy = m.namingStrategy
+			staticModule.restOperations = m.restOperations
+			staticModule.namingStrategy = m.namingStrategy
+			staticModule.restOperations = m.restOperations
 			injectModule := staticModule.NewModule()
 			if len(injectModule.providers) > 0 {
 				m.providers = append(injectModule.providers, m.providers...)
@@ -242,7 +303,7 @@ func (m *Module) NewModule() *Module {
 		if len(m.Middleware.middlewares) > 0 {
 			for _, controller := range m.controllers {
 				controllerName := reflect.TypeOf(controller).PkgPath()
-				m.Middleware.addREST(controllerName, &m.RESTMiddlewares)
+				m.Middleware.addREST(controllerName, m.restOperations, &m.RESTMiddlewares)
 				m.Middleware.addWS(controllerName, &m.WSMiddlewares)
 			}
 		}
@@ -250,6 +311,9 @@ func (m *Module) NewModule() *Module {
 		// set module prefixes
 		for _, controller := range m.controllers {
 			globalPrefixes[genControllerKey(m, controller)] = m.prefixes
+			if m.host != "" {
+				globalHosts[genControllerKey(m, controller)] = m.host
+			}
 		}
 
 		// inject local providers
@@ -328,6 +392,17 @@ func (m *Module) NewModule() *Module {
 						}
 					}
 
+					for controllerKey, globalHost := range globalHosts {
+						if getPkgFromControllerKey(controllerKey) == genFieldKey(reflect.TypeOf(controller)) && rest.GetHost() == "" {
+							rest.Host(globalHost)
+						}
+					}
+
+					if rest.GetNamingStrategy() == nil && m.namingStrategy != nil {
+						rest.NamingStrategy(m.namingStrategy)
+					}
+					rest.Operations(m.restOperations)
+
 					for j := 0; j < reflect.TypeOf(m.controllers[i]).NumMethod(); j++ {
 						methodName := reflect.TypeOf(m.controllers[i]).Method(j).Name
 
@@ -336,6 +411,47 @@ func (m *Module) NewModule() *Module {
 						rest.AddHandlerToRouterMap(modulePrefixes, methodName, handler)
 					}
 
+					// explicit routes must be bound
+					// to methods of this controller
+					for _, explicitRoute := range rest.GetRoutes() {
+						fnName := common.GetFnName(explicitRoute.Handler)
+						if _, ok := reflect.TypeOf(m.controllers[i]).MethodByName(fnName); !ok ||
+							common.GetHandlerName(explicitRoute.Handler) != genHandlerName(reflect.TypeOf(m.controllers[i]), fnName) {
+							panic(utils.FmtRed(
+								"%v route handler is not method of %v",
+								explicitRoute.Path,
+								reflect.TypeOf(m.controllers[i]).Name(),
+							))
+						}
+					}
+
+					// URLs are built by handler names,
+					// they are validated once all routes were registered
+					for _, handlerName := range rest.GetURLReferences() {
+						m.RESTURLReferences = append(m.RESTURLReferences, struct {
+							ControllerName string
+							Name           string
+						}{
+							ControllerName: reflect.TypeOf(m.controllers[i]).Name(),
+							Name:           handlerName,
+						})
+					}
+
+					// apply controller route param constraints
+					for _, constraintItem := range rest.GetConstraints() {
+						m.RESTConstraints = append(m.RESTConstraints, struct {
+							Method     string
+							Route      string
+							Key        string
+							Constraint routing.Constraint
+						}{
+							Method:     constraintItem.Method,
+							Route:      constraintItem.Route,
+							Key:        constraintItem.Key,
+							Constraint: constraintItem.Constraint,
+						})
+					}
+
 					// apply controller bound middlewares
 					for _, restModuleMiddleware := range m.RESTMiddlewares {
 						if restModuleMiddleware.controllerName == controllerName {
@@ -379,6 +495,26 @@ func (m *Module) NewModule() *Module {
 						}
 					}
 
+					// apply controller metadata
+					if _, loadedMetadata := reflect.TypeOf(m.controllers[i]).FieldByName(noInjectedFields[10]); loadedMetadata {
+						metadata := reflect.ValueOf(m.controllers[i]).FieldByName(noInjectedFields[10]).Interface().(common.Metadata)
+						for _, metadataItem := range metadata.GetRESTMetadata(&rest) {
+							m.RESTMetadata = append(m.RESTMetadata, struct {
+								Method    string
+								Route     string
+								Key       string
+								Value     any
+								IsHandler bool
+							}{
+								Method:    metadataItem.Method,
+								Route:     metadataItem.Route,
+								Key:       metadataItem.Key,
+								Value:     metadataItem.Value,
+								IsHandler: metadataItem.IsHandler,
+							})
+						}
+					}
+
 					// apply controller bound guard
 					if _, loadedGuard := reflect.TypeOf(m.controllers[i]).FieldByName(noInjectedFields[2]); loadedGuard {
 						guard := reflect.ValueOf(m.controllers[i]).FieldByName(noInjectedFields[2]).Interface().(common.Guard)
@@ -581,6 +717,16 @@ func (m *Module) NewModule() *Module {
 							Route:   routing.ToEndpoint(route),
 							Handler: handler,
 						})
+
+						m.RESTHandlerRoutes = append(m.RESTHandlerRoutes, struct {
+							Name   string
+							Method string
+							Route  string
+						}{
+							Name:   genHandlerName(reflect.TypeOf(m.controllers[i]), rest.PatternToFnNameMap[pattern]),
+							Method: method,
+							Route:  routing.ToEndpoint(route),
+						})
 					}
 				}
 
@@ -637,6 +783,26 @@ func (m *Module) NewModule() *Module {
 						}
 					}
 
+					// apply controller metadata
+					if _, loadedMetadata := reflect.TypeOf(m.controllers[i]).FieldByName(noInjectedFields[10]); loadedMetadata {
+						metadata := reflect.ValueOf(m.controllers[i]).FieldByName(noInjectedFields[10]).Interface().(common.Metadata)
+						for _, metadataItem := range metadata.GetWSMetadata(&ws) {
+							m.WSMetadata = append(m.WSMetadata, struct {
+								Subprotocol string
+								EventName   string
+								Key         string
+								Value       any
+								IsHandler   bool
+							}{
+								Subprotocol: ws.GetSubprotocol(),
+								EventName:   metadataItem.EventName,
+								Key:         metadataItem.Key,
+								Value:       metadataItem.Value,
+								IsHandler:   metadataItem.IsHandler,
+							})
+						}
+					}
+
 					// apply controller bound guard
 					if _, loadedGuard := reflect.TypeOf(m.controllers[i]).FieldByName(noInjectedFields[2]); loadedGuard {
 						guard := reflect.ValueOf(m.controllers[i]).FieldByName(noInjectedFields[2]).Interface().(common.Guard)
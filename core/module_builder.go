@@ -107,6 +107,15 @@ func (m *moduleBuilder) Build() *Module {
 			Route   string
 			Handler any
 		}{},
+		RESTHandlerRoutes: []struct {
+			Name   string
+			Method string
+			Route  string
+		}{},
+		RESTURLReferences: []struct {
+			ControllerName string
+			Name           string
+		}{},
 
 		WSMiddlewares: []struct {
 			controllerName string
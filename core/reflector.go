@@ -0,0 +1,98 @@
+package core
+
+import (
+	"net/http"
+
+	"github.com/dangduoc08/gogo/common"
+	"github.com/dangduoc08/gogo/ctx"
+	"github.com/dangduoc08/gogo/routing"
+)
+
+type handlerMetadata struct {
+	handler    map[string]any
+	controller map[string]any
+}
+
+// Reflector reads metadata set by SetMetadata
+// for handler of current request or WS event,
+// it's injectable into guards, interceptors,
+// exception filters and providers
+type Reflector struct {
+
+	// metadata of app by REST endpoints and WS events
+	restMetadataMap map[string]*handlerMetadata
+	wsMetadataMap   map[string]*handlerMetadata
+}
+
+func (reflector Reflector) NewProvider() Provider {
+	return reflector
+}
+
+// Get returns handler metadata,
+// fallback to controller metadata
+func (reflector Reflector) Get(c *ctx.Context, k string) any {
+	metadata := reflector.getHandlerMetadata(c)
+	if metadata == nil {
+		return nil
+	}
+
+	if v, ok := metadata.handler[k]; ok {
+		return v
+	}
+
+	return metadata.controller[k]
+}
+
+// GetAll returns both handler
+// and controller metadata
+func (reflector Reflector) GetAll(c *ctx.Context, k string) []any {
+	values := []any{}
+	metadata := reflector.getHandlerMetadata(c)
+	if metadata == nil {
+		return values
+	}
+
+	if v, ok := metadata.handler[k]; ok {
+		values = append(values, v)
+	}
+
+	if v, ok := metadata.controller[k]; ok {
+		values = append(values, v)
+	}
+
+	return values
+}
+
+func (reflector Reflector) Has(c *ctx.Context, k string) bool {
+	return len(reflector.GetAll(c, k)) > 0
+}
+
+func (reflector Reflector) getHandlerMetadata(c *ctx.Context) *handlerMetadata {
+	if c.GetType() == ctx.WSType && c.WS != nil {
+		return reflector.wsMetadataMap[common.ToWSEventName(c.WS.GetSubprotocol(), c.WS.Message.Event)]
+	}
+
+	metadata := reflector.restMetadataMap[routing.ToEndpoint(routing.AddMethodToRoute(c.GetRoute(), c.Method))]
+
+	// HEAD requests are handled by GET handlers
+	if metadata == nil && c.Method == http.MethodHead {
+		metadata = reflector.restMetadataMap[routing.ToEndpoint(routing.AddMethodToRoute(c.GetRoute(), http.MethodGet))]
+	}
+
+	return metadata
+}
+
+func addMetadata(metadataMap map[string]*handlerMetadata, key, k string, v any, isHandler bool) {
+	if metadataMap[key] == nil {
+		metadataMap[key] = &handlerMetadata{
+			handler:    make(map[string]any),
+			controller: make(map[string]any),
+		}
+	}
+
+	if isHandler {
+		metadataMap[key].handler[k] = v
+	} else {
+		metadataMap[key].controller[k] = v
+	}
+}
@@ -0,0 +1,64 @@
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dangduoc08/gogo/common"
+	"github.com/dangduoc08/gogo/ctx"
+	"github.com/dangduoc08/gogo/utils"
+)
+
+type reflectorGuard struct {
+	Reflector Reflector
+}
+
+func (instance reflectorGuard) CanActivate(c *ctx.Context) bool {
+	isPublic, _ := instance.Reflector.Get(c, "public").(bool)
+
+	return isPublic
+}
+
+type reflectorController struct {
+	common.REST
+	common.Guard
+	common.Metadata
+}
+
+func (instance reflectorController) NewController() Controller {
+	instance.BindGuard(reflectorGuard{})
+	instance.SetMetadata("public", true, instance.READ_reflector_articles)
+
+	return instance
+}
+
+func (instance reflectorController) READ_reflector_articles() string {
+	return "articles"
+}
+
+func (instance reflectorController) READ_reflector_drafts() string {
+	return "drafts"
+}
+
+func TestReflectorMetadata(t *testing.T) {
+	app := createTestApp(ModuleBuilder().Controllers(reflectorController{}).Build())
+
+	cases := map[string]int{
+		"/reflector_articles": http.StatusOK,
+		"/reflector_drafts":   http.StatusForbidden,
+	}
+
+	for path, expected := range cases {
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+		if w.Code != expected {
+			t.Errorf(utils.ErrorMessage(w.Code, expected, path+" should be guarded by metadata of app"))
+		}
+	}
+
+	if len(app.restMetadataMap) != 1 {
+		t.Errorf(utils.ErrorMessage(len(app.restMetadataMap), 1, "metadata should be stored by app"))
+	}
+}
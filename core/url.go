@@ -0,0 +1,94 @@
+package core
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/dangduoc08/gogo/common"
+	"github.com/dangduoc08/gogo/routing"
+	"github.com/dangduoc08/gogo/utils"
+)
+
+var routeParamRegExp = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// URLFor builds path of REST handler
+// e.g. app.URLFor(UserController{}.READ_users_BY_id, map[string]any{"id": 1}, nil)
+// with module and controller prefixes.
+// host of host bound routes isn't included,
+// version is included by URI versioning only.
+// it panics if handler wasn't registered
+// or params are missing,
+// declare handlers by REST.RequireURLs
+// to validate them once app was created
+func (app *App) URLFor(handler any, params map[string]any, query url.Values) string {
+	if handler == nil || reflect.TypeOf(handler).Kind() != reflect.Func {
+		panic(fmt.Errorf(utils.FmtRed("URLFor handler must be function, got %T", handler)))
+	}
+
+	handlerName := common.GetHandlerName(handler)
+	routes := app.restHandlerRoutes[handlerName]
+	if len(routes) == 0 {
+		panic(fmt.Errorf(utils.FmtRed("%v handler was not registered as REST handler", handlerName)))
+	}
+
+	_, route := routing.SplitHost(app.selectURLRoute(routes))
+	version, route := routing.SplitVersion(route)
+	if version != "" && app.versioning != nil && app.versioning.Type == URIVersioning {
+		route = "/" + app.versioning.Prefix + version + route
+	}
+
+	route = routeParamRegExp.ReplaceAllStringFunc(route, func(param string) string {
+		k := param[1 : len(param)-1]
+		v, ok := params[k]
+		if !ok {
+			panic(fmt.Errorf(utils.FmtRed("%v handler requires %v param", handlerName, k)))
+		}
+
+		return url.PathEscape(fmt.Sprint(v))
+	})
+
+	if route != "/" {
+		route = strings.TrimSuffix(route, "/")
+	}
+
+	if len(query) > 0 {
+		route += "?" + query.Encode()
+	}
+
+	return route
+}
+
+// selectURLRoute selects route of default version,
+// then neutral route
+// if handler was bound to several routes
+func (app *App) selectURLRoute(routes []string) string {
+	if len(routes) == 1 {
+		return routes[0]
+	}
+
+	sortedRoutes := append([]string{}, routes...)
+	sort.Strings(sortedRoutes)
+
+	defaultVersion := ""
+	if app.versioning != nil {
+		defaultVersion = app.versioning.DefaultVersion
+	}
+
+	for _, isSelected := range []func(string) bool{
+		func(version string) bool { return defaultVersion != "" && version == defaultVersion },
+		func(version string) bool { return version == "" },
+	} {
+		for _, route := range sortedRoutes {
+			_, path := routing.SplitHost(route)
+			if version, _ := routing.SplitVersion(path); isSelected(version) {
+				return route
+			}
+		}
+	}
+
+	return sortedRoutes[0]
+}
@@ -0,0 +1,108 @@
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/dangduoc08/gogo/common"
+	"github.com/dangduoc08/gogo/ctx"
+	"github.com/dangduoc08/gogo/utils"
+)
+
+type urlUserController struct {
+	common.REST
+}
+
+func (instance urlUserController) NewController() Controller {
+	instance.
+		Prefix("url-users").
+		RequireURLs(instance.READ_BY_id)
+
+	return instance
+}
+
+func (instance urlUserController) READ_BY_id(c *ctx.Context) string {
+	return c.Param().Get("id")
+}
+
+func (instance urlUserController) CREATE(c *ctx.Context) string {
+	location := c.URLFor(instance.READ_BY_id, map[string]any{"id": "a b"}, nil)
+	c.ResponseWriter.Header().Set("Location", location)
+
+	return location
+}
+
+type urlOrphanController struct {
+	common.REST
+}
+
+func (instance urlOrphanController) READ_url_orphans(c *ctx.Context) string {
+	return ""
+}
+
+type urlInvalidController struct {
+	common.REST
+}
+
+func (instance urlInvalidController) NewController() Controller {
+	instance.RequireURLs(urlOrphanController{}.READ_url_orphans)
+
+	return instance
+}
+
+func (instance urlInvalidController) READ_url_invalids(c *ctx.Context) string {
+	return ""
+}
+
+func TestURLFor(t *testing.T) {
+	controller := urlUserController{}
+	app := createTestApp(
+		ModuleBuilder().
+			Imports(ModuleBuilder().Controllers(controller).Build().Prefix("api")).
+			Build(),
+	)
+
+	actual := app.URLFor(controller.READ_BY_id, map[string]any{"id": 1}, url.Values{"expand": {"profile"}})
+	expected := "/api/url-users/1?expand=profile"
+	if actual != expected {
+		t.Errorf(utils.ErrorMessage(actual, expected, "URL should contain module and controller prefixes"))
+	}
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/url-users", nil))
+
+	expected = "/api/url-users/a%20b"
+	if location := w.Header().Get("Location"); location != expected {
+		t.Errorf(utils.ErrorMessage(location, expected, "c.URLFor should build escaped URL"))
+	}
+
+	func() {
+		defer func() {
+			if rec := recover(); rec == nil {
+				t.Errorf(utils.ErrorMessage(rec, "panic", "missing param should panic"))
+			}
+		}()
+		app.URLFor(controller.READ_BY_id, nil, nil)
+	}()
+
+	func() {
+		defer func() {
+			if rec := recover(); rec == nil {
+				t.Errorf(utils.ErrorMessage(rec, "panic", "unregistered handler should panic"))
+			}
+		}()
+		app.URLFor(urlOrphanController{}.READ_url_orphans, nil, nil)
+	}()
+}
+
+func TestURLForRequiredHandler(t *testing.T) {
+	defer func() {
+		if rec := recover(); rec == nil {
+			t.Errorf(utils.ErrorMessage(rec, "panic", "app creation should fail if required handler was not registered"))
+		}
+	}()
+
+	createTestApp(ModuleBuilder().Controllers(urlInvalidController{}).Build())
+}
@@ -0,0 +1,207 @@
+package core
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dangduoc08/gogo/ctx"
+	"github.com/dangduoc08/gogo/routing"
+	"github.com/dangduoc08/gogo/utils"
+)
+
+const (
+	URIVersioning       = "uri"
+	HeaderVersioning    = "header"
+	MediaTypeVersioning = "media_type"
+	CustomVersioning    = "custom"
+)
+
+// VersioningOptions selects how requested version is extracted.
+// URIVersioning reads first path segment e.g. /v2/users,
+// HeaderVersioning reads Header e.g. X-API-Version: 2,
+// MediaTypeVersioning reads Accept
+// e.g. application/vnd.app.v2+json or application/json;v=2,
+// CustomVersioning calls Extractor.
+// DefaultVersion is used when request has no version
+type VersioningOptions struct {
+	Type           string
+	Prefix         string
+	Header         string
+	Key            string
+	Extractor      func(*ctx.Context) string
+	DefaultVersion string
+	Deprecations   map[string]Deprecation
+}
+
+// Deprecation marks version as deprecated,
+// Date and Sunset are optional
+type Deprecation struct {
+	Date   time.Time
+	Sunset time.Time
+	Link   string
+}
+
+// UseVersioning enables versioned routes
+// which were bound by REST.Version
+func (app *App) UseVersioning(opts VersioningOptions) *App {
+	switch opts.Type {
+	case URIVersioning:
+		if opts.Prefix == "" {
+			opts.Prefix = "v"
+		}
+	case HeaderVersioning:
+		if opts.Header == "" {
+			opts.Header = "X-API-Version"
+		}
+	case MediaTypeVersioning:
+		if opts.Key == "" {
+			opts.Key = "v"
+		}
+	case CustomVersioning:
+		if opts.Extractor == nil {
+			panic(fmt.Errorf(utils.FmtRed("Extractor is required by %v versioning", CustomVersioning)))
+		}
+	default:
+		panic(fmt.Errorf(utils.FmtRed("unknown versioning type '%v'", opts.Type)))
+	}
+
+	app.versioning = &opts
+
+	return app
+}
+
+// extractVersion returns requested version
+// and path without version segment
+func (app *App) extractVersion(c *ctx.Context, requestedPath string) (string, string) {
+	if app.versioning == nil {
+		return "", requestedPath
+	}
+
+	version := ""
+	switch app.versioning.Type {
+	case URIVersioning:
+		version, requestedPath = getURIVersion(requestedPath, app.versioning.Prefix)
+	case HeaderVersioning:
+		version = strings.TrimSpace(c.Request.Header.Get(app.versioning.Header))
+	case MediaTypeVersioning:
+		version = getMediaTypeVersion(c.Request.Header.Values("Accept"), app.versioning.Key)
+	case CustomVersioning:
+		version = strings.TrimSpace(app.versioning.Extractor(c))
+	}
+
+	if version == "" {
+		version = app.versioning.DefaultVersion
+	}
+
+	return version, requestedPath
+}
+
+// matchVersion matches routes of requested version,
+// then neutral routes
+func (app *App) matchVersion(c *ctx.Context, requestedPath, method string) (bool, string, map[string][]int, []string, []ctx.Handler) {
+	version, versionedPath := app.extractVersion(c, requestedPath)
+
+	isMatched, matchedRoute, paramKeys, paramValues, handlers := app.route.MatchVersion(c.Request.Host, version, versionedPath, method)
+
+	// URI which looks like versioned
+	// but is declared as is
+	if !isMatched && versionedPath != requestedPath {
+		return app.route.MatchHost(c.Request.Host, requestedPath, method)
+	}
+
+	return isMatched, matchedRoute, paramKeys, paramValues, handlers
+}
+
+func (app *App) allowedVersionMethods(c *ctx.Context, requestedPath string) []string {
+	version, versionedPath := app.extractVersion(c, requestedPath)
+
+	allowedMethods := app.route.AllowedVersionMethods(c.Request.Host, version, versionedPath)
+	if len(allowedMethods) == 0 && versionedPath != requestedPath {
+		return app.route.AllowedHostMethods(c.Request.Host, requestedPath)
+	}
+
+	return allowedMethods
+}
+
+// setDeprecationHeaders sets Deprecation, Sunset and Link headers
+// if version of matched route was deprecated
+func (app *App) setDeprecationHeaders(c *ctx.Context, matchedRoute string) {
+	if app.versioning == nil || len(app.versioning.Deprecations) == 0 {
+		return
+	}
+
+	_, route := routing.SplitRoute(matchedRoute)
+	_, route = routing.SplitHost(route)
+	version, _ := routing.SplitVersion(route)
+
+	deprecation, ok := app.versioning.Deprecations[version]
+	if version == "" || !ok {
+		return
+	}
+
+	header := c.ResponseWriter.Header()
+	if deprecation.Date.IsZero() {
+		header.Set("Deprecation", "true")
+	} else {
+		header.Set("Deprecation", fmt.Sprintf("@%v", deprecation.Date.Unix()))
+	}
+
+	if !deprecation.Sunset.IsZero() {
+		header.Set("Sunset", deprecation.Sunset.UTC().Format(http.TimeFormat))
+	}
+
+	if deprecation.Link != "" {
+		header.Add("Link", fmt.Sprintf("<%v>; rel=\"deprecation\"", deprecation.Link))
+	}
+}
+
+// getURIVersion cuts version segment e.g. /v2/users,
+// version must start with digit
+func getURIVersion(requestedPath, prefix string) (string, string) {
+	seg, rest, _ := strings.Cut(strings.TrimPrefix(requestedPath, "/"), "/")
+
+	version, ok := strings.CutPrefix(seg, prefix)
+	if !ok || version == "" || version[0] < '0' || version[0] > '9' {
+		return "", requestedPath
+	}
+
+	return version, "/" + rest
+}
+
+// getMediaTypeVersion reads version from param key
+// or vendor subtype e.g. vnd.app.v2+json
+func getMediaTypeVersion(accepts []string, key string) string {
+	for _, accept := range accepts {
+		for _, mediaRange := range strings.Split(accept, ",") {
+			mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+			if err != nil {
+				continue
+			}
+
+			if version := strings.TrimSpace(params[key]); version != "" {
+				return version
+			}
+
+			_, subtype, _ := strings.Cut(mediaType, "/")
+			subtype, _, _ = strings.Cut(subtype, "+")
+			if !strings.HasPrefix(subtype, "vnd.") {
+				continue
+			}
+
+			// labels after vendor name,
+			// version may contain dots e.g. vnd.app.v2.1
+			labels := strings.Split(subtype, ".")
+			for i := 2; i < len(labels); i++ {
+				if version, ok := strings.CutPrefix(labels[i], key); ok &&
+					version != "" && version[0] >= '0' && version[0] <= '9' {
+					return strings.Join(append([]string{version}, labels[i+1:]...), ".")
+				}
+			}
+		}
+	}
+
+	return ""
+}
@@ -2,7 +2,6 @@ package ctx
 
 import (
 	"go/token"
-	"mime/multipart"
 	"reflect"
 
 	"github.com/dangduoc08/gogo/utils"
@@ -29,39 +28,23 @@ func BindFile(f File, s any) (map[string][]*DataFile, any) {
 					switch structField.Type.Kind() {
 
 					case reflect.Ptr:
-						if len(bindedValue) > 0 {
-							if fileHeader, ok := utils.ArrGet(bindedValue, bindedIndex); ok {
-								dataFile := []*DataFile{
-									{
-										FileHeader: fileHeader,
-										Index:      bindedIndex,
-										Size:       fileHeader.Size,
-										Total:      1,
-										Key:        bindedField,
-										Filename:   fileHeader.Filename,
-										Type:       fileHeader.Header.Get("Content-Type"),
-									},
-								}
+						if dataFile, ok := utils.ArrGet(bindedValue, bindedIndex); ok {
+							copiedDataFile := *dataFile
+							copiedDataFile.Total = 1
 
-								filteredFile[bindedField] = dataFile
-								setValueToStructField(dataFile[0])
-							}
+							filteredFile[bindedField] = []*DataFile{&copiedDataFile}
+							setValueToStructField(&copiedDataFile)
 						}
 						continue
 
 					case reflect.Slice:
-						dataFile := utils.ArrMap[*multipart.FileHeader, *DataFile](
+						dataFile := utils.ArrMap[*DataFile, *DataFile](
 							bindedValue,
-							func(fileHeader *multipart.FileHeader, index int) *DataFile {
-								return &DataFile{
-									FileHeader: fileHeader,
-									Index:      index,
-									Size:       fileHeader.Size,
-									Total:      len(bindedValue),
-									Key:        bindedField,
-									Filename:   fileHeader.Filename,
-									Type:       fileHeader.Header.Get("Content-Type"),
-								}
+							func(dataFile *DataFile, index int) *DataFile {
+								copiedDataFile := *dataFile
+								copiedDataFile.Index = index
+								copiedDataFile.Total = len(bindedValue)
+								return &copiedDataFile
 							})
 
 						filteredFile[bindedField] = dataFile
@@ -0,0 +1,96 @@
+package ctx
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dangduoc08/gogo/exception"
+)
+
+func GenerateETag(b []byte, isWeak bool) string {
+	hash := sha1.Sum(b)
+	etag := `"` + hex.EncodeToString(hash[:]) + `"`
+	if isWeak {
+		return "W/" + etag
+	}
+
+	return etag
+}
+
+// CheckPreconditions evaluates conditional headers
+// in order of RFC 9110 section 13.2.2.
+// returns 0 when request should be proceeded,
+// otherwise 304 or 412
+func CheckPreconditions(r *http.Request, etag string, lastModified time.Time) int {
+	isSafeMethod := r.Method == http.MethodGet || r.Method == http.MethodHead
+	lastModified = lastModified.Truncate(time.Second)
+
+	if ifMatch := r.Header.Get("If-Match"); ifMatch != "" {
+		if !matchETags(ifMatch, etag, false) {
+			return http.StatusPreconditionFailed
+		}
+	} else if ifUnmodifiedSince := r.Header.Get("If-Unmodified-Since"); ifUnmodifiedSince != "" && !lastModified.IsZero() {
+		if t, err := http.ParseTime(ifUnmodifiedSince); err == nil && lastModified.After(t) {
+			return http.StatusPreconditionFailed
+		}
+	}
+
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		if matchETags(ifNoneMatch, etag, true) {
+			if isSafeMethod {
+				return http.StatusNotModified
+			}
+			return http.StatusPreconditionFailed
+		}
+	} else if ifModifiedSince := r.Header.Get("If-Modified-Since"); ifModifiedSince != "" && isSafeMethod && !lastModified.IsZero() {
+		if t, err := http.ParseTime(ifModifiedSince); err == nil && !lastModified.After(t) {
+			return http.StatusNotModified
+		}
+	}
+
+	return 0
+}
+
+// CheckPreconditions sets ETag and Last-Modified of current resource
+// and rejects request by PreconditionFailedException
+// when If-Match or If-Unmodified-Since were not satisfied.
+// use it before applying changes in unsafe methods
+func (c *Context) CheckPreconditions(etag string, lastModified time.Time) {
+	responseHeader := c.ResponseWriter.Header()
+	if etag != "" {
+		responseHeader.Set("ETag", etag)
+	}
+	if !lastModified.IsZero() {
+		responseHeader.Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if CheckPreconditions(c.Request, etag, lastModified) == http.StatusPreconditionFailed {
+		panic(exception.PreconditionFailedException("Precondition failed"))
+	}
+}
+
+func matchETags(header, etag string, isWeakComparison bool) bool {
+	if etag == "" {
+		return false
+	}
+
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+
+		if isWeakComparison {
+			if strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		} else if !strings.HasPrefix(candidate, "W/") && !strings.HasPrefix(etag, "W/") && candidate == etag {
+			return true
+		}
+	}
+
+	return false
+}
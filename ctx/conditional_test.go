@@ -0,0 +1,45 @@
+package ctx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dangduoc08/gogo/utils"
+)
+
+func TestCheckPreconditions(t *testing.T) {
+	etag := GenerateETag([]byte("gogo"), false)
+	lastModified := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		method   string
+		header   map[string]string
+		expected int
+	}{
+		{http.MethodGet, map[string]string{"If-None-Match": etag}, http.StatusNotModified},
+		{http.MethodGet, map[string]string{"If-None-Match": "W/" + etag}, http.StatusNotModified},
+		{http.MethodGet, map[string]string{"If-None-Match": `"other"`}, 0},
+		{http.MethodPut, map[string]string{"If-None-Match": "*"}, http.StatusPreconditionFailed},
+		{http.MethodPut, map[string]string{"If-Match": etag}, 0},
+		{http.MethodPut, map[string]string{"If-Match": "W/" + etag}, http.StatusPreconditionFailed},
+		{http.MethodPut, map[string]string{"If-Match": `"other", *`}, 0},
+		{http.MethodDelete, map[string]string{"If-Unmodified-Since": lastModified.Add(-time.Hour).Format(http.TimeFormat)}, http.StatusPreconditionFailed},
+		{http.MethodDelete, map[string]string{"If-Unmodified-Since": lastModified.Format(http.TimeFormat)}, 0},
+		{http.MethodGet, map[string]string{"If-Modified-Since": lastModified.Format(http.TimeFormat)}, http.StatusNotModified},
+		{http.MethodGet, map[string]string{"If-Modified-Since": lastModified.Add(-time.Hour).Format(http.TimeFormat)}, 0},
+	}
+
+	for _, testCase := range cases {
+		r := httptest.NewRequest(testCase.method, "/", nil)
+		for k, v := range testCase.header {
+			r.Header.Set(k, v)
+		}
+
+		actual := CheckPreconditions(r, etag, lastModified)
+		if actual != testCase.expected {
+			t.Errorf(utils.ErrorMessage(actual, testCase.expected, testCase.method+" preconditions should be equal"))
+		}
+	}
+}
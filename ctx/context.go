@@ -14,6 +14,9 @@ type (
 	Handler  = func(*Context)
 	Next     = func()
 	Redirect = func(string)
+
+	// token issued by CSRF middleware
+	CSRFToken string
 )
 
 type Context struct {
@@ -22,14 +25,23 @@ type Context struct {
 
 	dataWriter DataWriter
 
-	body        Body
-	form        Form
-	file        File
-	query       Query
-	header      Header
-	param       Param
-	ParamKeys   map[string][]int
-	ParamValues []string
+	body             Body
+	form             Form
+	file             File
+	query            Query
+	header           Header
+	cookie           Cookie
+	param            Param
+	sse              *SSEStream
+	multipartOptions *MultipartOptions
+	cspNonce         string
+	csrfToken        string
+	secrets          []string
+	urlBuilder       URLBuilder
+	deferredFns      []func()
+	beforeFinishFns  []func()
+	ParamKeys        map[string][]int
+	ParamValues      []string
 
 	route string
 	ID    string
@@ -70,7 +82,7 @@ func (c *Context) Text(data string, args ...any) {
 		responseWriter: c.ResponseWriter,
 	}
 	c.dataWriter.WriteData(c.Code)
-	c.Event.Emit(REQUEST_FINISHED, c)
+	c.finish()
 }
 
 func (c *Context) JSON(data ...any) {
@@ -79,7 +91,7 @@ func (c *Context) JSON(data ...any) {
 		responseWriter: c.ResponseWriter,
 	}
 	c.dataWriter.WriteData(c.Code)
-	c.Event.Emit(REQUEST_FINISHED, c)
+	c.finish()
 }
 
 func (c *Context) JSONP(data ...any) {
@@ -95,11 +107,18 @@ func (c *Context) JSONP(data ...any) {
 		callback:       callback,
 	}
 	c.dataWriter.WriteData(c.Code)
-	c.Event.Emit(REQUEST_FINISHED, c)
+	c.finish()
 }
 
+// GetRoute returns matched route without method,
+// method of route may differ from request method
+// e.g. HEAD requests handled by GET handlers
 func (c *Context) GetRoute() string {
-	return strings.Replace(c.route, "/["+c.Method+"]/", "", 1)
+	if i := strings.LastIndex(c.route, "/["); i > -1 && strings.HasSuffix(c.route, "]/") {
+		return c.route[:i]
+	}
+
+	return c.route
 }
 
 func (c *Context) SetRoute(route string) *Context {
@@ -110,10 +129,48 @@ func (c *Context) SetRoute(route string) *Context {
 func (c *Context) Redirect(url string) {
 	c.Status(http.StatusMovedPermanently)
 	http.Redirect(c.ResponseWriter, c.Request, url, c.Code)
+	c.finish()
+}
+
+// Defer registers function which will be invoked
+// once request was handled,
+// the latest registered function runs first
+func (c *Context) Defer(fn func()) {
+	c.deferredFns = append(c.deferredFns, fn)
+}
+
+// BeforeFinish registers function which runs
+// before REQUEST_FINISHED is emitted,
+// middlewares which buffer response
+// decide final status by it
+func (c *Context) BeforeFinish(fn func()) {
+	c.beforeFinishFns = append(c.beforeFinishFns, fn)
+}
+
+// finish emits REQUEST_FINISHED,
+// functions registered by BeforeFinish run once
+// and may raise exceptions
+func (c *Context) finish() {
+	beforeFinishFns := c.beforeFinishFns
+	c.beforeFinishFns = nil
+	for i := len(beforeFinishFns) - 1; i >= 0; i-- {
+		beforeFinishFns[i]()
+	}
+
 	c.Event.Emit(REQUEST_FINISHED, c)
 }
 
 func (c *Context) Reset() {
+	if c.sse != nil {
+		c.sse.Close()
+	}
+
+	for i := len(c.deferredFns) - 1; i >= 0; i-- {
+		c.deferredFns[i]()
+	}
+	c.deferredFns = nil
+	c.beforeFinishFns = nil
+
 	c.Code = http.StatusOK
 	c.route = ""
 	c.Type = ""
@@ -124,7 +181,12 @@ func (c *Context) Reset() {
 	c.file = nil
 	c.query = nil
 	c.header = nil
+	c.cookie = nil
 	c.param = nil
+	c.sse = nil
+	c.multipartOptions = nil
+	c.cspNonce = ""
+	c.csrfToken = ""
 	c.ParamKeys = nil
 	c.ParamValues = nil
 	c.Next = nil
@@ -157,3 +219,12 @@ func (c *Context) SetID(id string) *Context {
 func (c *Context) GetID() string {
 	return c.ID
 }
+
+func (c *Context) SetCSRFToken(token string) *Context {
+	c.csrfToken = token
+	return c
+}
+
+func (c *Context) GetCSRFToken() string {
+	return c.csrfToken
+}
@@ -0,0 +1,220 @@
+package ctx
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var ErrCookieSecretsRequired = errors.New("cookie secrets are required to sign or encrypt cookies")
+
+type Cookie map[string][]string
+
+type CookieOptions struct {
+	Name   string
+	Value  string
+	Path   string
+	Domain string
+
+	// negative value deletes cookie,
+	// zero value means session cookie
+	MaxAge  time.Duration
+	Expires time.Time
+
+	SameSite   http.SameSite
+	IsSecure   bool
+	IsHTTPOnly bool
+}
+
+func (c *Context) Cookies() Cookie {
+	if c.cookie != nil {
+		return c.cookie
+	}
+
+	c.cookie = Cookie{}
+	for _, cookie := range c.Request.Cookies() {
+		c.cookie[cookie.Name] = append(c.cookie[cookie.Name], cookie.Value)
+	}
+
+	return c.cookie
+}
+
+func (cookie Cookie) Get(k string) string {
+	values := cookie[k]
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (cookie Cookie) Has(k string) bool {
+	_, ok := cookie[k]
+	return ok
+}
+
+func (cookie Cookie) Bind(s any) (any, []FieldLevel) {
+	return BindStrArr(cookie, &[]FieldLevel{}, s)
+}
+
+// SetCookieSecrets sets secrets used by signed and encrypted cookies,
+// the first secret signs and encrypts,
+// the others are used to verify and decrypt old cookies
+func (c *Context) SetCookieSecrets(secrets []string) *Context {
+	c.secrets = secrets
+	return c
+}
+
+func (c *Context) GetCookieSecrets() []string {
+	return c.secrets
+}
+
+// Cookie returns value of request cookie,
+// empty if cookie was not sent
+func (c *Context) Cookie(name string) string {
+	return c.Cookies().Get(name)
+}
+
+func (c *Context) SetCookie(opts CookieOptions) {
+	cookie := &http.Cookie{
+		Name:     opts.Name,
+		Value:    opts.Value,
+		Path:     opts.Path,
+		Domain:   opts.Domain,
+		Expires:  opts.Expires,
+		SameSite: opts.SameSite,
+		Secure:   opts.IsSecure,
+		HttpOnly: opts.IsHTTPOnly,
+	}
+
+	if cookie.Path == "" {
+		cookie.Path = "/"
+	}
+
+	if opts.MaxAge < 0 {
+		cookie.MaxAge = -1
+	} else if opts.MaxAge > 0 {
+		cookie.MaxAge = int(opts.MaxAge.Seconds())
+		if cookie.Expires.IsZero() {
+			cookie.Expires = time.Now().Add(opts.MaxAge)
+		}
+	}
+
+	http.SetCookie(c.ResponseWriter, cookie)
+}
+
+func (c *Context) ClearCookie(opts CookieOptions) {
+	opts.Value = ""
+	opts.MaxAge = -1
+	opts.Expires = time.Unix(0, 0)
+	c.SetCookie(opts)
+}
+
+// SignedCookie returns value of cookie
+// which was signed by any of cookie secrets
+func (c *Context) SignedCookie(name string) (string, bool) {
+	signedValue := c.Cookie(name)
+	i := strings.LastIndex(signedValue, ".")
+	if i < 0 {
+		return "", false
+	}
+
+	value, signature := signedValue[:i], signedValue[i+1:]
+	for _, secret := range c.secrets {
+		if hmac.Equal([]byte(signCookie(name, value, secret)), []byte(signature)) {
+			return value, true
+		}
+	}
+
+	return "", false
+}
+
+// SetSignedCookie sets cookie with HMAC signature,
+// value is readable by client but can't be modified
+func (c *Context) SetSignedCookie(opts CookieOptions) {
+	if len(c.secrets) == 0 {
+		panic(ErrCookieSecretsRequired)
+	}
+
+	opts.Value = opts.Value + "." + signCookie(opts.Name, opts.Value, c.secrets[0])
+	c.SetCookie(opts)
+}
+
+// EncryptedCookie returns decrypted value of cookie
+// which was encrypted by any of cookie secrets
+func (c *Context) EncryptedCookie(name string) (string, bool) {
+	encryptedValue, err := base64.RawURLEncoding.DecodeString(c.Cookie(name))
+	if err != nil {
+		return "", false
+	}
+
+	for _, secret := range c.secrets {
+		aead, err := newCookieAEAD(secret)
+		if err != nil {
+			continue
+		}
+
+		nonceSize := aead.NonceSize()
+		if len(encryptedValue) < nonceSize {
+			return "", false
+		}
+
+		nonce, ciphertext := encryptedValue[:nonceSize], encryptedValue[nonceSize:]
+		if value, err := aead.Open(nil, nonce, ciphertext, []byte(name)); err == nil {
+			return string(value), true
+		}
+	}
+
+	return "", false
+}
+
+// SetEncryptedCookie sets cookie encrypted by AES-GCM,
+// value is neither readable nor modifiable by client
+func (c *Context) SetEncryptedCookie(opts CookieOptions) {
+	if len(c.secrets) == 0 {
+		panic(ErrCookieSecretsRequired)
+	}
+
+	aead, err := newCookieAEAD(c.secrets[0])
+	if err != nil {
+		panic(err)
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		panic(err)
+	}
+
+	// cookie name is authenticated
+	// to prevent swapping values between cookies
+	encryptedValue := aead.Seal(nonce, nonce, []byte(opts.Value), []byte(opts.Name))
+	opts.Value = base64.RawURLEncoding.EncodeToString(encryptedValue)
+	c.SetCookie(opts)
+}
+
+func signCookie(name, value, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(name + "=" + value))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// AES-256 key is derived from secret
+// so secrets can be any length
+func newCookieAEAD(secret string) (cipher.AEAD, error) {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("cookie-encryption"))
+
+	block, err := aes.NewCipher(mac.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,89 @@
+package ctx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dangduoc08/gogo/utils"
+)
+
+func newCookieContext(secrets []string, cookies []*http.Cookie) (*Context, *httptest.ResponseRecorder) {
+	c, recorder := newStreamContext(http.MethodGet, "/", nil)
+	for _, cookie := range cookies {
+		c.Request.AddCookie(cookie)
+	}
+	c.SetCookieSecrets(secrets)
+
+	return c, recorder
+}
+
+func TestContextSignedCookie(t *testing.T) {
+	c, recorder := newCookieContext([]string{"old_secret"}, nil)
+	c.SetSignedCookie(CookieOptions{
+		Name:  "user",
+		Value: "gogo",
+	})
+	signedCookie := recorder.Result().Cookies()[0]
+
+	if !strings.HasPrefix(signedCookie.Value, "gogo.") {
+		t.Errorf(utils.ErrorMessage(signedCookie.Value, "gogo.<signature>", "value should be readable"))
+	}
+
+	// rotated secret
+	c, _ = newCookieContext([]string{"new_secret", "old_secret"}, []*http.Cookie{signedCookie})
+	if value, ok := c.SignedCookie("user"); !ok || value != "gogo" {
+		t.Errorf(utils.ErrorMessage(value, "gogo", "cookie signed by old secret should be verified"))
+	}
+
+	tamperedCookie := &http.Cookie{Name: "user", Value: strings.Replace(signedCookie.Value, "gogo", "admin", 1)}
+	c, _ = newCookieContext([]string{"old_secret"}, []*http.Cookie{tamperedCookie})
+	if _, ok := c.SignedCookie("user"); ok {
+		t.Errorf(utils.ErrorMessage(ok, false, "tampered cookie should be rejected"))
+	}
+}
+
+func TestContextEncryptedCookie(t *testing.T) {
+	c, recorder := newCookieContext([]string{"secret"}, nil)
+	c.SetEncryptedCookie(CookieOptions{
+		Name:  "token",
+		Value: "gogo",
+	})
+	encryptedCookie := recorder.Result().Cookies()[0]
+
+	if strings.Contains(encryptedCookie.Value, "gogo") {
+		t.Errorf(utils.ErrorMessage(encryptedCookie.Value, "<encrypted>", "value should be encrypted"))
+	}
+
+	c, _ = newCookieContext([]string{"secret"}, []*http.Cookie{encryptedCookie})
+	if value, ok := c.EncryptedCookie("token"); !ok || value != "gogo" {
+		t.Errorf(utils.ErrorMessage(value, "gogo", "cookie should be decrypted"))
+	}
+
+	// value can't be moved to another cookie
+	swappedCookie := &http.Cookie{Name: "session", Value: encryptedCookie.Value}
+	c, _ = newCookieContext([]string{"secret"}, []*http.Cookie{swappedCookie})
+	if _, ok := c.EncryptedCookie("session"); ok {
+		t.Errorf(utils.ErrorMessage(ok, false, "swapped cookie should be rejected"))
+	}
+}
+
+func TestContextCookies(t *testing.T) {
+	c, recorder := newCookieContext(nil, []*http.Cookie{
+		{Name: "lang", Value: "vi"},
+	})
+
+	if lang := c.Cookie("lang"); lang != "vi" {
+		t.Errorf(utils.ErrorMessage(lang, "vi", "cookie should be read"))
+	}
+
+	if c.Cookies().Has("theme") {
+		t.Errorf(utils.ErrorMessage(true, false, "cookie should not exist"))
+	}
+
+	c.ClearCookie(CookieOptions{Name: "lang"})
+	if setCookie := recorder.Header().Get("Set-Cookie"); !strings.Contains(setCookie, "Max-Age=0") {
+		t.Errorf(utils.ErrorMessage(setCookie, "lang=; Max-Age=0", "cookie should be cleared"))
+	}
+}
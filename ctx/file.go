@@ -1,6 +1,8 @@
 package ctx
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"mime/multipart"
 
@@ -24,22 +26,52 @@ type DataFile struct {
 	Filename string
 	Type     string
 	Dest     string
+
+	// filled by MemoryStorage
+	Content []byte
+
+	isStreamed bool
+	storage    Storage
 }
 
-type File map[string][]*multipart.FileHeader
+type File map[string][]*DataFile
 
+// File returns uploaded files,
+// multipart body is streamed into storage
+// on the first call of File or Form
 func (c *Context) File() File {
 	if c.file != nil {
 		return c.file
 	}
 
 	if c.Request.MultipartForm != nil {
-		c.file = c.Request.MultipartForm.File
+		c.file = fromMultipartForm(c.Request.MultipartForm)
+	} else if c.form == nil && c.isMultipart() {
+		c.parseMultipart()
 	}
 
 	return c.file
 }
 
+// Open opens content of file
+// which was stored in memory, by storage
+// or parsed by ParseMultipartForm
+func (dataFile *DataFile) Open() (multipart.File, error) {
+	if dataFile.Content != nil {
+		return memoryFile{bytes.NewReader(dataFile.Content)}, nil
+	}
+
+	if opener, ok := dataFile.storage.(storageOpener); ok && dataFile.Dest != "" {
+		return opener.Open(dataFile)
+	}
+
+	if dataFile.FileHeader != nil && !dataFile.isStreamed {
+		return dataFile.FileHeader.Open()
+	}
+
+	return nil, errors.New("file content is not available")
+}
+
 func (files File) Bind(s any) any {
 	filteredFile, newStructuredData := BindFile(files, s)
 
@@ -61,9 +93,8 @@ func (files File) Bind(s any) any {
 	if fileHandler, ok := s.(fileHandler); ok {
 		for _, dataFileArr := range filteredFile {
 			for _, dataFile := range dataFileArr {
-				src, err := dataFile.FileHeader.Open()
+				src, err := dataFile.Open()
 				if err != nil {
-					src.Close()
 					panic(exception.BadRequestException(err.Error()))
 				}
 				fileHandler.Store(dataFile, src)
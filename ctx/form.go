@@ -21,7 +21,10 @@ func (c *Context) Form() Form {
 	contentType := c.Header().Get("Content-Type")
 
 	if strings.Contains(contentType, multipartFormData) {
-		e = c.Request.ParseMultipartForm(defaultMaxMemory)
+		if c.Request.MultipartForm == nil {
+			c.parseMultipart()
+			return c.form
+		}
 	} else if strings.Contains(contentType, applicationXWWWFormUrlencoded) {
 		e = c.Request.ParseForm()
 	}
@@ -0,0 +1,303 @@
+package ctx
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"github.com/dangduoc08/gogo/exception"
+)
+
+const defaultMaxFieldsSize = 10 << 20
+
+var errMultipartTooLarge = errors.New("multipart: content too large")
+
+// MultipartOptions limits streamed multipart bodies,
+// zero values use defaults,
+// negative sizes or counts disable limits.
+// AllowedTypes are matched against sniffed MIME types
+// and may contain wildcards such as "image/*"
+type MultipartOptions struct {
+	MaxFiles      int
+	MaxFileSize   int64
+	MaxTotalSize  int64
+	MaxFields     int
+	MaxFieldsSize int64
+	AllowedTypes  []string
+	Storage       Storage
+}
+
+func loadMultipartOptions(opts MultipartOptions) MultipartOptions {
+	if opts.MaxTotalSize == 0 {
+		opts.MaxTotalSize = defaultMaxMemory
+	}
+
+	if opts.MaxFieldsSize == 0 {
+		opts.MaxFieldsSize = defaultMaxFieldsSize
+	}
+
+	if opts.Storage == nil {
+		opts.Storage = MemoryStorage{}
+	}
+
+	return opts
+}
+
+// SetMultipartOptions sets limits and storage
+// for multipart body of current request,
+// it takes effect only before Form or File were read
+func (c *Context) SetMultipartOptions(opts MultipartOptions) *Context {
+	multipartOptions := loadMultipartOptions(opts)
+	c.multipartOptions = &multipartOptions
+	return c
+}
+
+func (c *Context) isMultipart() bool {
+	return strings.Contains(c.Request.Header.Get("Content-Type"), multipartFormData)
+}
+
+// parseMultipart streams parts of body,
+// files are sniffed, checked and stored part by part
+// without buffering whole body
+func (c *Context) parseMultipart() {
+	multipartOptions := c.multipartOptions
+	if multipartOptions == nil {
+		defaultMultipartOptions := loadMultipartOptions(MultipartOptions{})
+		multipartOptions = &defaultMultipartOptions
+	}
+	storage := multipartOptions.Storage
+
+	form := Form{}
+	file := File{}
+	storedFiles := []*DataFile{}
+
+	fail := func(e any) {
+		for _, dataFile := range storedFiles {
+			storage.Remove(c, dataFile)
+		}
+		panic(e)
+	}
+
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		fail(exception.BadRequestException(err.Error()))
+	}
+
+	totalFields := 0
+	var fieldsSize, totalSize int64
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			fail(exception.BadRequestException(err.Error()))
+		}
+
+		k := part.FormName()
+		if k == "" {
+			part.Close()
+			continue
+		}
+
+		if part.FileName() == "" {
+			totalFields++
+			if multipartOptions.MaxFields > 0 && totalFields > multipartOptions.MaxFields {
+				fail(exception.RequestEntityTooLargeException(fmt.Sprintf("Too many form fields. Maximum is %v", multipartOptions.MaxFields)))
+			}
+
+			v, err := readPart(part, remainingSize(multipartOptions.MaxFieldsSize, fieldsSize))
+			if err == errMultipartTooLarge {
+				fail(exception.RequestEntityTooLargeException("Form fields are too large"))
+			}
+			if err != nil {
+				fail(exception.BadRequestException(err.Error()))
+			}
+
+			fieldsSize += int64(len(v))
+			form.Add(k, string(v))
+			continue
+		}
+
+		if multipartOptions.MaxFiles > 0 && len(storedFiles) >= multipartOptions.MaxFiles {
+			fail(exception.RequestEntityTooLargeException(fmt.Sprintf("Too many files. Maximum is %v", multipartOptions.MaxFiles)))
+		}
+
+		head := make([]byte, sniffLen)
+		n, err := io.ReadFull(part, head)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			fail(exception.BadRequestException(err.Error()))
+		}
+		head = head[:n]
+
+		contentType := http.DetectContentType(head)
+		if !isTypeAllowed(multipartOptions.AllowedTypes, contentType) {
+			fail(exception.UnsupportedMediaTypeException(fmt.Sprintf("Unsupported file type '%v' of '%v'", contentType, part.FileName())))
+		}
+
+		dataFile := &DataFile{
+			FileHeader: &multipart.FileHeader{
+				Filename: part.FileName(),
+				Header:   part.Header,
+			},
+			Index:      len(file[k]),
+			Key:        k,
+			Filename:   part.FileName(),
+			Type:       contentType,
+			isStreamed: true,
+			storage:    storage,
+		}
+
+		src := &limitedReader{
+			r:       io.MultiReader(bytes.NewReader(head), part),
+			maxSize: remainingSize(multipartOptions.MaxFileSize, 0),
+		}
+		if totalLimit := remainingSize(multipartOptions.MaxTotalSize, totalSize); totalLimit >= 0 &&
+			(src.maxSize < 0 || totalLimit < src.maxSize) {
+			src.maxSize = totalLimit
+		}
+
+		err = storage.Store(c, dataFile, src)
+		if err != nil || src.isExceeded {
+			storage.Remove(c, dataFile)
+			if src.isExceeded {
+				fail(exception.RequestEntityTooLargeException(fmt.Sprintf("File '%v' is too large", dataFile.Filename)))
+			}
+			fail(err)
+		}
+
+		dataFile.Size = src.n
+		dataFile.FileHeader.Size = src.n
+		totalSize += src.n
+
+		storedFiles = append(storedFiles, dataFile)
+		file[k] = append(file[k], dataFile)
+	}
+
+	for _, dataFiles := range file {
+		for _, dataFile := range dataFiles {
+			dataFile.Total = len(dataFiles)
+		}
+	}
+
+	// keep net/http behaviors
+	// where body values precede query values
+	c.Request.PostForm = map[string][]string{}
+	c.Request.Form = map[string][]string{}
+	for k, v := range form {
+		c.Request.PostForm[k] = append([]string{}, v...)
+		c.Request.Form[k] = append([]string{}, v...)
+	}
+	for k, v := range c.Request.URL.Query() {
+		c.Request.Form[k] = append(c.Request.Form[k], v...)
+	}
+
+	c.form = Form(c.Request.Form)
+	c.file = file
+}
+
+// fromMultipartForm converts files
+// which were parsed by ParseMultipartForm
+func fromMultipartForm(multipartForm *multipart.Form) File {
+	file := File{}
+
+	for k, fileHeaders := range multipartForm.File {
+		for i, fileHeader := range fileHeaders {
+			file[k] = append(file[k], &DataFile{
+				FileHeader: fileHeader,
+				Index:      i,
+				Size:       fileHeader.Size,
+				Total:      len(fileHeaders),
+				Key:        k,
+				Filename:   fileHeader.Filename,
+				Type:       fileHeader.Header.Get("Content-Type"),
+			})
+		}
+	}
+
+	return file
+}
+
+func remainingSize(maxSize, usedSize int64) int64 {
+	if maxSize <= 0 {
+		return -1
+	}
+
+	if usedSize >= maxSize {
+		return 0
+	}
+
+	return maxSize - usedSize
+}
+
+func readPart(part *multipart.Part, maxSize int64) ([]byte, error) {
+	src := &limitedReader{
+		r:       part,
+		maxSize: maxSize,
+	}
+
+	v, err := io.ReadAll(src)
+	if src.isExceeded {
+		return nil, errMultipartTooLarge
+	}
+
+	return v, err
+}
+
+func isTypeAllowed(allowedTypes []string, contentType string) bool {
+	if len(allowedTypes) == 0 {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	for _, allowedType := range allowedTypes {
+		allowedType = strings.ToLower(strings.TrimSpace(allowedType))
+
+		if allowedType == "*/*" || allowedType == "*" || allowedType == mediaType {
+			return true
+		}
+
+		if prefix, ok := strings.CutSuffix(allowedType, "/*"); ok &&
+			strings.HasPrefix(mediaType, prefix+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// limitedReader counts read bytes
+// and fails once maxSize was exceeded,
+// negative maxSize means unlimited
+type limitedReader struct {
+	r          io.Reader
+	n          int64
+	maxSize    int64
+	isExceeded bool
+}
+
+func (lr *limitedReader) Read(p []byte) (int, error) {
+	if lr.isExceeded {
+		return 0, errMultipartTooLarge
+	}
+
+	n, err := lr.r.Read(p)
+	lr.n += int64(n)
+
+	if lr.maxSize >= 0 && lr.n > lr.maxSize {
+		lr.isExceeded = true
+		return n, errMultipartTooLarge
+	}
+
+	return n, err
+}
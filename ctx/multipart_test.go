@@ -0,0 +1,145 @@
+package ctx
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dangduoc08/gogo/exception"
+	"github.com/dangduoc08/gogo/utils"
+)
+
+var pngContent = []byte("\x89PNG\x0D\x0A\x1A\x0Aimage content")
+
+func newMultipartContext(fields map[string]string, files map[string][]byte) *Context {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for k, v := range fields {
+		writer.WriteField(k, v)
+	}
+	for filename, content := range files {
+		part, _ := writer.CreateFormFile("files", filename)
+		part.Write(content)
+	}
+	writer.Close()
+
+	c := NewContext()
+	c.Request = httptest.NewRequest(http.MethodPost, "/uploads?page=1", body)
+	c.Request.Header.Set("Content-Type", writer.FormDataContentType())
+	c.ResponseWriter = httptest.NewRecorder()
+
+	return c
+}
+
+func getHTTPException(fn func()) (httpException exception.HTTPException) {
+	defer func() {
+		httpException, _ = recover().(exception.HTTPException)
+	}()
+	fn()
+
+	return
+}
+
+func TestContextMultipartMemory(t *testing.T) {
+	c := newMultipartContext(map[string]string{"name": "gogo"}, map[string][]byte{
+		"avatar.jpg": pngContent,
+	})
+
+	if name := c.Form().Get("name"); name != "gogo" {
+		t.Errorf(utils.ErrorMessage(name, "gogo", "form field should be parsed"))
+	}
+
+	if page := c.Form().Get("page"); page != "1" {
+		t.Errorf(utils.ErrorMessage(page, "1", "query should be merged into form"))
+	}
+
+	dataFiles := c.File()["files"]
+	if len(dataFiles) != 1 {
+		t.Fatalf(utils.ErrorMessage(len(dataFiles), 1, "file should be parsed"))
+	}
+
+	dataFile := dataFiles[0]
+	if dataFile.Type != "image/png" {
+		t.Errorf(utils.ErrorMessage(dataFile.Type, "image/png", "type should be sniffed instead of extension"))
+	}
+
+	if dataFile.Size != int64(len(pngContent)) {
+		t.Errorf(utils.ErrorMessage(dataFile.Size, len(pngContent), "size should be counted"))
+	}
+
+	src, err := dataFile.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, _ := io.ReadAll(src)
+	if !bytes.Equal(content, pngContent) {
+		t.Errorf(utils.ErrorMessage(content, pngContent, "content should be stored in memory"))
+	}
+}
+
+func TestContextMultipartDisk(t *testing.T) {
+	dir := t.TempDir()
+	c := newMultipartContext(nil, map[string][]byte{
+		"../avatar.png": pngContent,
+	})
+	c.SetMultipartOptions(MultipartOptions{
+		Storage: NewDiskStorage(dir, OriginalFilename),
+	})
+
+	dataFile := c.File()["files"][0]
+	expectedDest := filepath.Join(dir, "avatar.png")
+	if dataFile.Dest != expectedDest {
+		t.Errorf(utils.ErrorMessage(dataFile.Dest, expectedDest, "file should be stored inside dir"))
+	}
+
+	if content, _ := os.ReadFile(dataFile.Dest); !bytes.Equal(content, pngContent) {
+		t.Errorf(utils.ErrorMessage(content, pngContent, "content should be stored on disk"))
+	}
+}
+
+func TestContextMultipartLimits(t *testing.T) {
+	cases := []struct {
+		opts           MultipartOptions
+		files          map[string][]byte
+		expectedStatus int
+	}{
+		{
+			opts:           MultipartOptions{MaxFileSize: 8},
+			files:          map[string][]byte{"avatar.png": pngContent},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			opts:           MultipartOptions{MaxFiles: 1},
+			files:          map[string][]byte{"a.png": pngContent, "b.png": pngContent},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			opts:           MultipartOptions{AllowedTypes: []string{"image/*"}},
+			files:          map[string][]byte{"avatar.png": []byte("plain text")},
+			expectedStatus: http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, testCase := range cases {
+		dir := t.TempDir()
+		testCase.opts.Storage = NewDiskStorage(dir)
+		c := newMultipartContext(nil, testCase.files)
+		c.SetMultipartOptions(testCase.opts)
+
+		httpException := getHTTPException(func() {
+			c.File()
+		})
+		if code, _ := httpException.GetHTTPStatus(); code != testCase.expectedStatus {
+			t.Errorf(utils.ErrorMessage(code, testCase.expectedStatus, "status should be equal"))
+		}
+
+		if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+			t.Errorf(utils.ErrorMessage(len(entries), 0, "stored files should be removed"))
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package ctx
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+)
+
+// CSPNonce returns random nonce of current request,
+// use it in script and style tags
+// allowed by Content-Security-Policy
+func (c *Context) CSPNonce() string {
+	if c.cspNonce == "" {
+		b := make([]byte, 16)
+		if _, err := rand.Read(b); err != nil {
+			panic(err)
+		}
+		c.cspNonce = base64.StdEncoding.EncodeToString(b)
+	}
+
+	return c.cspNonce
+}
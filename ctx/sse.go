@@ -0,0 +1,268 @@
+package ctx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	textEventStream     = "text/event-stream"
+	lastEventIDHeader   = "Last-Event-ID"
+	lastEventIDQuery    = "lastEventId"
+	DefaultSSEHeartbeat = 15 * time.Second
+)
+
+var ErrSSEClosed = errors.New("sse stream was closed")
+
+type SSEEvent struct {
+	ID      string
+	Event   string
+	Data    any
+	Retry   time.Duration
+	Comment string
+}
+
+type SSEStream struct {
+	mu          *sync.Mutex
+	c           *Context
+	controller  *http.ResponseController
+	reqCtx      context.Context
+	ticker      *time.Ticker
+	done        chan struct{}
+	closed      bool
+	finished    bool
+	lastEventID string
+}
+
+// SSE switches response into text/event-stream mode.
+// headers are flushed immediately,
+// heartbeat comments are sent every DefaultSSEHeartbeat
+// and stream is closed once client disconnected
+func (c *Context) SSE() *SSEStream {
+	if c.sse != nil {
+		return c.sse
+	}
+
+	responseHeader := c.ResponseWriter.Header()
+	responseHeader.Set("Content-Type", textEventStream)
+	responseHeader.Set("Cache-Control", "no-cache")
+	responseHeader.Set("Connection", "keep-alive")
+	responseHeader.Set("X-Accel-Buffering", "no")
+	c.ResponseWriter.WriteHeader(c.Code)
+
+	lastEventID := c.Request.Header.Get(lastEventIDHeader)
+	if lastEventID == "" {
+		lastEventID = c.Request.URL.Query().Get(lastEventIDQuery)
+	}
+
+	c.sse = &SSEStream{
+		mu:          &sync.Mutex{},
+		c:           c,
+		controller:  http.NewResponseController(c.ResponseWriter),
+		reqCtx:      c.Request.Context(),
+		ticker:      time.NewTicker(DefaultSSEHeartbeat),
+		done:        make(chan struct{}),
+		lastEventID: lastEventID,
+	}
+	c.sse.controller.Flush()
+
+	go c.sse.keepAlive()
+
+	return c.sse
+}
+
+// Heartbeat changes interval of heartbeat comments,
+// d <= 0 will disable heartbeat
+func (s *SSEStream) Heartbeat(d time.Duration) *SSEStream {
+	if d <= 0 {
+		s.ticker.Stop()
+	} else {
+		s.ticker.Reset(d)
+	}
+
+	return s
+}
+
+// LastEventID returns ID which client sent to resume the stream
+func (s *SSEStream) LastEventID() string {
+	return s.lastEventID
+}
+
+// Done closed when stream was closed
+// or client disconnected
+func (s *SSEStream) Done() <-chan struct{} {
+	return s.done
+}
+
+// Send writes event into stream,
+// error is returned if Data couldn't be encoded
+func (s *SSEStream) Send(e SSEEvent) error {
+	buf, err := e.bytes()
+	if err != nil {
+		return err
+	}
+
+	return s.write(buf)
+}
+
+func (s *SSEStream) Comment(comment string) error {
+	buf, _ := SSEEvent{Comment: comment}.bytes()
+
+	return s.write(buf)
+}
+
+// Pipe writes events from channel into stream
+// until channel closed or client disconnected
+func (s *SSEStream) Pipe(events <-chan SSEEvent) {
+	for {
+		select {
+		case <-s.done:
+			return
+		case e, ok := <-events:
+			if !ok {
+				s.Close()
+				return
+			}
+
+			if err := s.Send(e); err != nil {
+				s.Close()
+				return
+			}
+		}
+	}
+}
+
+// Close closes stream and emits REQUEST_FINISHED once,
+// it must be called from request goroutine
+func (s *SSEStream) Close() {
+	s.shutdown()
+
+	s.mu.Lock()
+	if s.finished {
+		s.mu.Unlock()
+		return
+	}
+	s.finished = true
+	s.mu.Unlock()
+
+	s.c.finish()
+}
+
+// shutdown only signals done,
+// it's safe to be called from keepAlive goroutine
+func (s *SSEStream) shutdown() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+	s.closed = true
+	s.ticker.Stop()
+	close(s.done)
+}
+
+func (s *SSEStream) write(buf []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrSSEClosed
+	}
+
+	if _, err := s.c.ResponseWriter.Write(buf); err != nil {
+		return err
+	}
+
+	return s.controller.Flush()
+}
+
+func (s *SSEStream) keepAlive() {
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-s.reqCtx.Done():
+
+			// client closed connection,
+			// REQUEST_FINISHED is emitted by request goroutine
+			s.shutdown()
+			return
+		case <-s.ticker.C:
+			if err := s.Comment("heartbeat"); err != nil {
+				s.shutdown()
+				return
+			}
+		}
+	}
+}
+
+func (e SSEEvent) bytes() ([]byte, error) {
+	var b strings.Builder
+
+	if e.Comment != "" {
+		for _, line := range splitSSELines(e.Comment) {
+			b.WriteString(": " + line + "\n")
+		}
+	}
+
+	if e.ID != "" {
+		b.WriteString("id: " + removeSSENewlines(e.ID) + "\n")
+	}
+
+	if e.Event != "" {
+		b.WriteString("event: " + removeSSENewlines(e.Event) + "\n")
+	}
+
+	if e.Retry > 0 {
+		b.WriteString("retry: " + strconv.FormatInt(e.Retry.Milliseconds(), 10) + "\n")
+	}
+
+	if e.Data != nil {
+		data, err := toSSEData(e.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, line := range splitSSELines(data) {
+			b.WriteString("data: " + line + "\n")
+		}
+	}
+
+	b.WriteString("\n")
+
+	return []byte(b.String()), nil
+}
+
+func toSSEData(data any) (string, error) {
+	switch d := data.(type) {
+	case string:
+		return d, nil
+	case []byte:
+		return string(d), nil
+	case fmt.Stringer:
+		return d.String(), nil
+	}
+
+	jsonBuf, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBuf), nil
+}
+
+func splitSSELines(str string) []string {
+	return strings.Split(strings.ReplaceAll(str, "\r\n", "\n"), "\n")
+}
+
+func removeSSENewlines(str string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(str)
+}
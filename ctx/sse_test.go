@@ -0,0 +1,138 @@
+package ctx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dangduoc08/gogo/utils"
+)
+
+func TestSSEEventBytes(t *testing.T) {
+	cases := map[string]SSEEvent{
+		"id: 1\nevent: update\nretry: 3000\ndata: hello\n\n": {
+			ID:    "1",
+			Event: "update",
+			Retry: 3 * time.Second,
+			Data:  "hello",
+		},
+		"data: line 1\ndata: line 2\n\n": {
+			Data: "line 1\r\nline 2",
+		},
+		"data: {\"name\":\"gogo\"}\n\n": {
+			Data: Map{"name": "gogo"},
+		},
+		": heartbeat\n\n": {
+			Comment: "heartbeat",
+		},
+		"id: 12\n\n": {
+			ID: "1\n2",
+		},
+	}
+
+	for expected, e := range cases {
+		buf, err := e.bytes()
+		if err != nil {
+			t.Errorf(utils.ErrorMessage(err, nil, "sse event should be encoded"))
+		}
+
+		actual := string(buf)
+		if actual != expected {
+			t.Errorf(utils.ErrorMessage(actual, expected, "sse event should be equal"))
+		}
+	}
+}
+
+func TestSSEStreamPipe(t *testing.T) {
+	c := NewContext()
+	c.Event = NewEvent()
+	c.Request = httptest.NewRequest(http.MethodGet, "/events?lastEventId=9", nil)
+	recorder := httptest.NewRecorder()
+	c.ResponseWriter = recorder
+
+	stream := c.SSE()
+	if stream.LastEventID() != "9" {
+		t.Errorf(utils.ErrorMessage(stream.LastEventID(), "9", "last event id should be resolved from query"))
+	}
+
+	events := make(chan SSEEvent, 2)
+	events <- SSEEvent{ID: "10", Data: "foo"}
+	events <- SSEEvent{ID: "11", Data: "bar"}
+	close(events)
+	stream.Pipe(events)
+
+	select {
+	case <-stream.Done():
+	default:
+		t.Errorf("stream should be closed once channel was closed")
+	}
+
+	if contentType := recorder.Header().Get("Content-Type"); contentType != textEventStream {
+		t.Errorf(utils.ErrorMessage(contentType, textEventStream, "content type should be equal"))
+	}
+
+	expected := "id: 10\ndata: foo\n\nid: 11\ndata: bar\n\n"
+	if actual := recorder.Body.String(); actual != expected {
+		t.Errorf(utils.ErrorMessage(actual, expected, "stream body should be equal"))
+	}
+
+	if err := stream.Send(SSEEvent{Data: "baz"}); err != ErrSSEClosed {
+		t.Errorf(utils.ErrorMessage(err, ErrSSEClosed, "send after close should be rejected"))
+	}
+}
+
+func TestSSEStreamSendError(t *testing.T) {
+	c := NewContext()
+	c.Event = NewEvent()
+	c.Request = httptest.NewRequest(http.MethodGet, "/events", nil)
+	recorder := httptest.NewRecorder()
+	c.ResponseWriter = recorder
+
+	stream := c.SSE()
+	defer stream.Close()
+
+	if err := stream.Send(SSEEvent{Data: make(chan int)}); err == nil {
+		t.Errorf(utils.ErrorMessage(err, "json error", "send should return encoding error"))
+	}
+
+	if actual := recorder.Body.String(); actual != "" {
+		t.Errorf(utils.ErrorMessage(actual, "", "nothing should be written"))
+	}
+}
+
+func TestSSEStreamClientDisconnect(t *testing.T) {
+	reqCtx, cancel := context.WithCancel(context.Background())
+
+	c := NewContext()
+	c.Event = NewEvent()
+	c.Request = httptest.NewRequest(http.MethodGet, "/events", nil).WithContext(reqCtx)
+	c.ResponseWriter = httptest.NewRecorder()
+
+	finishedCount := 0
+	c.Event.On(REQUEST_FINISHED, func(args ...any) {
+		finishedCount++
+	})
+
+	stream := c.SSE()
+	cancel()
+
+	select {
+	case <-stream.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("stream should be done once client disconnected")
+	}
+
+	// keepAlive only signals done
+	if finishedCount != 0 {
+		t.Errorf(utils.ErrorMessage(finishedCount, 0, "REQUEST_FINISHED should not be emitted by keepAlive"))
+	}
+
+	stream.Close()
+	c.Reset()
+
+	if finishedCount != 1 {
+		t.Errorf(utils.ErrorMessage(finishedCount, 1, "REQUEST_FINISHED should be emitted once"))
+	}
+}
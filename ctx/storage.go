@@ -0,0 +1,128 @@
+package ctx
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Storage stores streamed multipart files
+// and fills DataFile.Dest.
+// Storage may implement Open(*DataFile) (multipart.File, error)
+// to make stored files readable by DataFile.Open
+type Storage interface {
+	Store(c *Context, dataFile *DataFile, src io.Reader) error
+	Remove(c *Context, dataFile *DataFile) error
+}
+
+type storageOpener interface {
+	Open(dataFile *DataFile) (multipart.File, error)
+}
+
+type FilenameFunc = func(*DataFile) string
+
+// RandomFilename keeps extension of original filename
+func RandomFilename(dataFile *DataFile) string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+
+	return hex.EncodeToString(b) + strings.ToLower(filepath.Ext(filepath.Base(dataFile.Filename)))
+}
+
+// OriginalFilename stores file by its base name,
+// storing fails if file already exists
+func OriginalFilename(dataFile *DataFile) string {
+	return dataFile.Filename
+}
+
+type DiskStorage struct {
+	Dir      string
+	Filename FilenameFunc
+}
+
+func NewDiskStorage(dir string, filename ...FilenameFunc) *DiskStorage {
+	diskStorage := &DiskStorage{
+		Dir:      dir,
+		Filename: RandomFilename,
+	}
+
+	if len(filename) > 0 && filename[0] != nil {
+		diskStorage.Filename = filename[0]
+	}
+
+	return diskStorage
+}
+
+func (diskStorage *DiskStorage) Store(c *Context, dataFile *DataFile, src io.Reader) error {
+	if err := os.MkdirAll(diskStorage.Dir, 0755); err != nil {
+		return err
+	}
+
+	// directories in filename are ignored
+	// to prevent path traversal
+	filename := filepath.Base(filepath.Clean("/" + diskStorage.Filename(dataFile)))
+	if filename == "/" || filename == "." {
+		return errors.New("invalid filename")
+	}
+
+	dest := filepath.Join(diskStorage.Dir, filename)
+	dst, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	dataFile.Dest = dest
+
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+
+	return dst.Close()
+}
+
+func (diskStorage *DiskStorage) Remove(c *Context, dataFile *DataFile) error {
+	if dataFile.Dest == "" {
+		return nil
+	}
+
+	return os.Remove(dataFile.Dest)
+}
+
+func (diskStorage *DiskStorage) Open(dataFile *DataFile) (multipart.File, error) {
+	return os.Open(dataFile.Dest)
+}
+
+// MemoryStorage keeps files in DataFile.Content,
+// it should be used with size limits
+type MemoryStorage struct{}
+
+func (memoryStorage MemoryStorage) Store(c *Context, dataFile *DataFile, src io.Reader) error {
+	content, err := io.ReadAll(src)
+	if err != nil {
+		return err
+	}
+	dataFile.Content = content
+
+	return nil
+}
+
+func (memoryStorage MemoryStorage) Remove(c *Context, dataFile *DataFile) error {
+	dataFile.Content = nil
+	return nil
+}
+
+type memoryFile struct {
+	*bytes.Reader
+}
+
+func (memoryFile) Close() error {
+	return nil
+}
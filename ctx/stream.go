@@ -0,0 +1,162 @@
+package ctx
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"time"
+
+	"github.com/dangduoc08/gogo/exception"
+)
+
+const sniffLen = 512
+
+type Download struct {
+	Name    string
+	Reader  io.Reader
+	ModTime time.Time
+}
+
+// Stream writes reader into response.
+// io.ReadSeeker supports Range and conditional requests
+// unless status other than 200 was set,
+// other readers are copied as chunks with status as is.
+// nil reader raises NotFoundException
+func (c *Context) Stream(r io.Reader) {
+	mustReader(r)
+
+	name := ""
+	modTime := time.Time{}
+
+	if file, ok := r.(fs.File); ok {
+		if fileInfo, err := file.Stat(); err == nil {
+			name = fileInfo.Name()
+			modTime = fileInfo.ModTime()
+		}
+	}
+
+	c.serveReader(name, modTime, r)
+}
+
+// Download same as Stream
+// but response will be saved as attachment by browsers
+func (c *Context) Download(d Download) {
+	mustReader(d.Reader)
+
+	name := d.Name
+	modTime := d.ModTime
+
+	if file, ok := d.Reader.(fs.File); ok && (name == "" || modTime.IsZero()) {
+		if fileInfo, err := file.Stat(); err == nil {
+			if name == "" {
+				name = fileInfo.Name()
+			}
+			if modTime.IsZero() {
+				modTime = fileInfo.ModTime()
+			}
+		}
+	}
+
+	contentDisposition := "attachment"
+	if name != "" {
+		contentDisposition = mime.FormatMediaType("attachment", map[string]string{
+			"filename": filepath.Base(name),
+		})
+	}
+	c.ResponseWriter.Header().Set("Content-Disposition", contentDisposition)
+
+	c.serveReader(name, modTime, d.Reader)
+}
+
+func (c *Context) Bytes(b []byte) {
+	c.serveReader("", time.Time{}, bytes.NewReader(b))
+}
+
+func (c *Context) serveReader(name string, modTime time.Time, r io.Reader) {
+	if closer, ok := r.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	// http.ServeContent decides status itself,
+	// status set by handler e.g. 201 is kept
+	// by copying reader instead
+	if readSeeker, ok := r.(io.ReadSeeker); ok && c.Code == http.StatusOK {
+
+		// http.ServeContent takes care
+		// Range, If-Modified-Since, If-Range headers
+		// and sniffing Content-Type
+		http.ServeContent(c.ResponseWriter, c.Request, name, modTime, readSeeker)
+		c.finish()
+		return
+	}
+
+	responseHeader := c.ResponseWriter.Header()
+	responseHeader.Set("Accept-Ranges", "none")
+
+	if !modTime.IsZero() {
+		responseHeader.Set("Last-Modified", modTime.UTC().Format(http.TimeFormat))
+
+		if c.Code == http.StatusOK && isNotModified(c.Request, modTime) {
+			responseHeader.Del("Content-Type")
+			c.Status(http.StatusNotModified)
+			c.ResponseWriter.WriteHeader(c.Code)
+			c.finish()
+			return
+		}
+	}
+
+	// sniff first 512 bytes
+	// when Content-Type can't be resolved by extension
+	sniffBuf := []byte{}
+	if responseHeader.Get("Content-Type") == "" {
+		contentType := mime.TypeByExtension(filepath.Ext(name))
+		if contentType == "" {
+			sniffBuf = make([]byte, sniffLen)
+			n, _ := io.ReadFull(r, sniffBuf)
+			sniffBuf = sniffBuf[:n]
+			contentType = http.DetectContentType(sniffBuf)
+		}
+		responseHeader.Set("Content-Type", contentType)
+	}
+
+	c.ResponseWriter.WriteHeader(c.Code)
+	if c.Request.Method != http.MethodHead {
+		c.ResponseWriter.Write(sniffBuf)
+		io.Copy(c.ResponseWriter, r)
+	}
+	c.finish()
+}
+
+func mustReader(r io.Reader) {
+	if r == nil {
+		panic(exception.NotFoundException("File not found"))
+	}
+
+	// typed nil e.g. (*os.File)(nil)
+	if v := reflect.ValueOf(r); v.Kind() == reflect.Pointer && v.IsNil() {
+		panic(exception.NotFoundException("File not found"))
+	}
+}
+
+func isNotModified(r *http.Request, modTime time.Time) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+
+	ifModifiedSince := r.Header.Get("If-Modified-Since")
+	if ifModifiedSince == "" || r.Header.Get("If-None-Match") != "" {
+		return false
+	}
+
+	t, err := http.ParseTime(ifModifiedSince)
+	if err != nil {
+		return false
+	}
+
+	// Last-Modified header truncates sub-second precision
+	return !modTime.Truncate(time.Second).After(t)
+}
@@ -0,0 +1,143 @@
+package ctx
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dangduoc08/gogo/exception"
+	"github.com/dangduoc08/gogo/utils"
+)
+
+func newStreamContext(method, target string, header map[string]string) (*Context, *httptest.ResponseRecorder) {
+	c := NewContext()
+	c.Event = NewEvent()
+	c.Request = httptest.NewRequest(method, target, nil)
+	for k, v := range header {
+		c.Request.Header.Set(k, v)
+	}
+	recorder := httptest.NewRecorder()
+	c.ResponseWriter = recorder
+
+	return c, recorder
+}
+
+func TestContextDownloadRange(t *testing.T) {
+	c, recorder := newStreamContext(http.MethodGet, "/reports", map[string]string{
+		"Range": "bytes=0-4",
+	})
+
+	c.Download(Download{
+		Name:   "reports/2024.csv",
+		Reader: strings.NewReader("id,name\n1,gogo\n"),
+	})
+
+	if recorder.Code != http.StatusPartialContent {
+		t.Errorf(utils.ErrorMessage(recorder.Code, http.StatusPartialContent, "status should be equal"))
+	}
+
+	if body := recorder.Body.String(); body != "id,na" {
+		t.Errorf(utils.ErrorMessage(body, "id,na", "partial body should be equal"))
+	}
+
+	expectedDisposition := `attachment; filename=2024.csv`
+	if contentDisposition := recorder.Header().Get("Content-Disposition"); contentDisposition != expectedDisposition {
+		t.Errorf(utils.ErrorMessage(contentDisposition, expectedDisposition, "content disposition should be equal"))
+	}
+
+	if contentType := recorder.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "text/csv") {
+		t.Errorf(utils.ErrorMessage(contentType, "text/csv", "content type should be resolved by extension"))
+	}
+}
+
+func TestContextStreamReader(t *testing.T) {
+	c, recorder := newStreamContext(http.MethodGet, "/images", nil)
+	c.Stream(io.MultiReader(strings.NewReader("\x89PNG\x0D\x0A\x1A\x0A"), strings.NewReader("rest")))
+
+	if contentType := recorder.Header().Get("Content-Type"); contentType != "image/png" {
+		t.Errorf(utils.ErrorMessage(contentType, "image/png", "content type should be sniffed"))
+	}
+
+	if acceptRanges := recorder.Header().Get("Accept-Ranges"); acceptRanges != "none" {
+		t.Errorf(utils.ErrorMessage(acceptRanges, "none", "unseekable reader should not accept ranges"))
+	}
+
+	if body := recorder.Body.String(); body != "\x89PNG\x0D\x0A\x1A\x0Arest" {
+		t.Errorf(utils.ErrorMessage(body, "\x89PNG\x0D\x0A\x1A\x0Arest", "body should be equal"))
+	}
+}
+
+func TestContextDownloadNotModified(t *testing.T) {
+	modTime := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	c, recorder := newStreamContext(http.MethodGet, "/reports", map[string]string{
+		"If-Modified-Since": modTime.Add(time.Hour).Format(http.TimeFormat),
+	})
+
+	c.Download(Download{
+		Name:    "report.txt",
+		Reader:  io.MultiReader(strings.NewReader("report")),
+		ModTime: modTime,
+	})
+
+	if recorder.Code != http.StatusNotModified {
+		t.Errorf(utils.ErrorMessage(recorder.Code, http.StatusNotModified, "status should be equal"))
+	}
+
+	if recorder.Body.Len() != 0 {
+		t.Errorf(utils.ErrorMessage(recorder.Body.String(), "", "body should be empty"))
+	}
+}
+
+func TestContextStreamNilReader(t *testing.T) {
+	cases := map[string]func(c *Context){
+		"download without reader": func(c *Context) {
+			c.Download(Download{Name: "report.txt"})
+		},
+		"typed nil reader": func(c *Context) {
+			c.Stream((*os.File)(nil))
+		},
+	}
+
+	for desc, fn := range cases {
+		c, recorder := newStreamContext(http.MethodGet, "/reports", nil)
+
+		func() {
+			defer func() {
+				httpException, ok := recover().(exception.HTTPException)
+				if code, _ := httpException.GetHTTPStatus(); !ok || code != http.StatusNotFound {
+					t.Errorf(utils.ErrorMessage(code, http.StatusNotFound, desc+" should raise NotFoundException"))
+				}
+			}()
+			fn(c)
+		}()
+
+		if recorder.Body.Len() != 0 {
+			t.Errorf(utils.ErrorMessage(recorder.Body.String(), "", desc+" should write nothing"))
+		}
+	}
+}
+
+func TestContextStreamStatus(t *testing.T) {
+	modTime := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	c, recorder := newStreamContext(http.MethodPost, "/reports", map[string]string{
+		"If-Modified-Since": modTime.Add(time.Hour).Format(http.TimeFormat),
+	})
+
+	c.Status(http.StatusCreated).Download(Download{
+		Name:    "report.txt",
+		Reader:  strings.NewReader("report"),
+		ModTime: modTime,
+	})
+
+	if recorder.Code != http.StatusCreated {
+		t.Errorf(utils.ErrorMessage(recorder.Code, http.StatusCreated, "status set by handler should be kept"))
+	}
+
+	if recorder.Body.String() != "report" {
+		t.Errorf(utils.ErrorMessage(recorder.Body.String(), "report", "body should be equal"))
+	}
+}
@@ -0,0 +1,26 @@
+package ctx
+
+import (
+	"net/url"
+)
+
+// URLBuilder builds URL of REST handler,
+// it's set by app for each request
+type URLBuilder = func(handler any, params map[string]any, query url.Values) string
+
+func (c *Context) SetURLBuilder(urlBuilder URLBuilder) *Context {
+	c.urlBuilder = urlBuilder
+	return c
+}
+
+// URLFor builds URL of REST handler
+// e.g. c.URLFor(instance.READ_users_BY_id, map[string]any{"id": 1}, nil),
+// it panics if handler wasn't registered
+// or params are missing
+func (c *Context) URLFor(handler any, params map[string]any, query url.Values) string {
+	if c.urlBuilder == nil {
+		panic("URL builder was not set")
+	}
+
+	return c.urlBuilder(handler, params, query)
+}
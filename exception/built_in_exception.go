@@ -88,3 +88,7 @@ func PreconditionFailedException(response any, opts ...any) HTTPException {
 func MisdirectedRequestException(response any, opts ...any) HTTPException {
 	return NewHTTPException(response, strconv.Itoa(http.StatusMisdirectedRequest), opts...)
 }
+
+func TooManyRequestsException(response any, opts ...any) HTTPException {
+	return NewHTTPException(response, strconv.Itoa(http.StatusTooManyRequests), opts...)
+}
@@ -0,0 +1,82 @@
+package middlewares
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dangduoc08/gogo/ctx"
+)
+
+type CacheControlOptions struct {
+	MaxAge               time.Duration
+	SMaxAge              time.Duration
+	StaleWhileRevalidate time.Duration
+	StaleIfError         time.Duration
+	IsPublic             bool
+	IsPrivate            bool
+	IsNoCache            bool
+	IsNoStore            bool
+	IsNoTransform        bool
+	IsMustRevalidate     bool
+	IsProxyRevalidate    bool
+	IsImmutable          bool
+}
+
+func (opts CacheControlOptions) String() string {
+	directives := []string{}
+
+	if opts.IsPublic {
+		directives = append(directives, "public")
+	}
+	if opts.IsPrivate {
+		directives = append(directives, "private")
+	}
+	if opts.IsNoCache {
+		directives = append(directives, "no-cache")
+	}
+	if opts.IsNoStore {
+		directives = append(directives, "no-store")
+	}
+	if opts.IsNoTransform {
+		directives = append(directives, "no-transform")
+	}
+	if opts.MaxAge > 0 {
+		directives = append(directives, "max-age="+strconv.FormatInt(int64(opts.MaxAge.Seconds()), 10))
+	}
+	if opts.SMaxAge > 0 {
+		directives = append(directives, "s-maxage="+strconv.FormatInt(int64(opts.SMaxAge.Seconds()), 10))
+	}
+	if opts.StaleWhileRevalidate > 0 {
+		directives = append(directives, "stale-while-revalidate="+strconv.FormatInt(int64(opts.StaleWhileRevalidate.Seconds()), 10))
+	}
+	if opts.StaleIfError > 0 {
+		directives = append(directives, "stale-if-error="+strconv.FormatInt(int64(opts.StaleIfError.Seconds()), 10))
+	}
+	if opts.IsMustRevalidate {
+		directives = append(directives, "must-revalidate")
+	}
+	if opts.IsProxyRevalidate {
+		directives = append(directives, "proxy-revalidate")
+	}
+	if opts.IsImmutable {
+		directives = append(directives, "immutable")
+	}
+
+	return strings.Join(directives, ", ")
+}
+
+// CacheControl declares Cache-Control policy,
+// bind it per handler through module middleware:
+// module.Middleware.Apply(middlewares.CacheControl(opts), controller.READ_users)
+func CacheControl(opts CacheControlOptions) func(*ctx.Context) {
+	cacheControl := opts.String()
+
+	return func(c *ctx.Context) {
+		if c.GetType() == ctx.HTTPType && cacheControl != "" {
+			c.ResponseWriter.Header().Set("Cache-Control", cacheControl)
+		}
+
+		c.Next()
+	}
+}
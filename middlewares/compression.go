@@ -0,0 +1,397 @@
+package middlewares
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/dangduoc08/gogo/ctx"
+	"github.com/dangduoc08/gogo/exception"
+)
+
+const (
+	EncodingGzip    = "gzip"
+	EncodingDeflate = "deflate"
+	EncodingBrotli  = "br"
+)
+
+type (
+	Encoder = func(w io.Writer, level int) (io.WriteCloser, error)
+	Decoder = func(r io.Reader) (io.ReadCloser, error)
+)
+
+type CompressionOptions struct {
+	Level        int
+	MinSize      int
+	ContentTypes []string
+
+	// by default gzip and deflate are supported,
+	// plug brotli or any encodings via Encoders/Decoders
+	// priorities are used when client accepts encodings with same q-value
+	Encoders   map[string]Encoder
+	Decoders   map[string]Decoder
+	Priorities []string
+
+	// limit decompressed request body size, 0 = unlimited
+	MaxDecompressedSize int64
+}
+
+var defaultCompressionContentTypes = []string{
+	"text/*",
+	"application/json",
+	"application/javascript",
+	"application/xml",
+	"application/xhtml+xml",
+	"application/rss+xml",
+	"application/atom+xml",
+	"application/wasm",
+	"image/svg+xml",
+}
+
+var defaultEncoders = map[string]Encoder{
+	EncodingGzip: func(w io.Writer, level int) (io.WriteCloser, error) {
+		return gzip.NewWriterLevel(w, level)
+	},
+	EncodingDeflate: func(w io.Writer, level int) (io.WriteCloser, error) {
+		return zlib.NewWriterLevel(w, level)
+	},
+}
+
+var defaultDecoders = map[string]Decoder{
+	EncodingGzip: func(r io.Reader) (io.ReadCloser, error) {
+		return gzip.NewReader(r)
+	},
+	EncodingDeflate: func(r io.Reader) (io.ReadCloser, error) {
+		return zlib.NewReader(r)
+	},
+}
+
+func loadCompressionOptions(opts []CompressionOptions) CompressionOptions {
+	compressionOptions := CompressionOptions{}
+	if len(opts) > 0 {
+		compressionOptions = opts[0]
+	}
+
+	if compressionOptions.Level == 0 {
+		compressionOptions.Level = flate.DefaultCompression
+	}
+
+	if compressionOptions.MinSize == 0 {
+		compressionOptions.MinSize = 1024
+	}
+
+	if len(compressionOptions.ContentTypes) == 0 {
+		compressionOptions.ContentTypes = defaultCompressionContentTypes
+	}
+
+	encoders := map[string]Encoder{}
+	for encoding, encoder := range defaultEncoders {
+		encoders[encoding] = encoder
+	}
+	for encoding, encoder := range compressionOptions.Encoders {
+		encoders[strings.ToLower(encoding)] = encoder
+	}
+	compressionOptions.Encoders = encoders
+
+	decoders := map[string]Decoder{}
+	for encoding, decoder := range defaultDecoders {
+		decoders[encoding] = decoder
+	}
+	for encoding, decoder := range compressionOptions.Decoders {
+		decoders[strings.ToLower(encoding)] = decoder
+	}
+	compressionOptions.Decoders = decoders
+
+	if len(compressionOptions.Priorities) == 0 {
+		compressionOptions.Priorities = []string{EncodingBrotli, EncodingGzip, EncodingDeflate}
+	}
+
+	return compressionOptions
+}
+
+// Compression negotiates response encoding by Accept-Encoding
+// and decompresses request bodies by Content-Encoding
+func Compression(opts ...CompressionOptions) func(*ctx.Context) {
+	compressionOptions := loadCompressionOptions(opts)
+
+	return func(c *ctx.Context) {
+		if c.GetType() != ctx.HTTPType {
+			c.Next()
+			return
+		}
+
+		if !decompressRequest(c, compressionOptions) {
+			return
+		}
+
+		c.ResponseWriter.Header().Add("Vary", "Accept-Encoding")
+
+		encoding := negotiateEncoding(c.Request.Header.Get("Accept-Encoding"), compressionOptions)
+		if encoding != "" && c.Request.Method != http.MethodHead {
+			compressWriter := &compressWriter{
+				ResponseWriter: c.ResponseWriter,
+				opts:           compressionOptions,
+				encoding:       encoding,
+				statusCode:     http.StatusOK,
+			}
+			c.ResponseWriter = compressWriter
+			c.Defer(func() {
+				compressWriter.Close()
+			})
+		}
+
+		c.Next()
+	}
+}
+
+func decompressRequest(c *ctx.Context, opts CompressionOptions) bool {
+	contentEncoding := strings.ToLower(strings.TrimSpace(c.Request.Header.Get("Content-Encoding")))
+	if contentEncoding == "" || contentEncoding == "identity" || c.Request.Body == nil {
+		return true
+	}
+
+	decoder, ok := opts.Decoders[contentEncoding]
+	if !ok {
+		writeCompressionException(c, exception.UnsupportedMediaTypeException(
+			"Unsupported Content-Encoding: "+contentEncoding,
+		))
+		return false
+	}
+
+	decodedBody, err := decoder(c.Request.Body)
+	if err != nil {
+		writeCompressionException(c, exception.BadRequestException(
+			"Invalid "+contentEncoding+" request body",
+		))
+		return false
+	}
+
+	if opts.MaxDecompressedSize > 0 {
+		decodedBody = http.MaxBytesReader(c.ResponseWriter, decodedBody, opts.MaxDecompressedSize)
+	}
+
+	c.Request.Body = decodedBody
+	c.Request.ContentLength = -1
+	c.Request.Header.Del("Content-Encoding")
+	c.Request.Header.Del("Content-Length")
+
+	return true
+}
+
+func writeCompressionException(c *ctx.Context, httpException exception.HTTPException) {
+	httpCode, _ := httpException.GetHTTPStatus()
+	c.Status(httpCode).JSON(ctx.Map{
+		"code":    httpException.GetCode(),
+		"error":   httpException.Error(),
+		"message": httpException.GetResponse(),
+	})
+}
+
+func negotiateEncoding(acceptEncoding string, opts CompressionOptions) string {
+	if acceptEncoding == "" {
+		return ""
+	}
+
+	qValues := map[string]float64{}
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		encoding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		encoding = strings.ToLower(strings.TrimSpace(encoding))
+		if encoding == "" {
+			continue
+		}
+
+		q := 1.0
+		if k, v, ok := strings.Cut(strings.TrimSpace(params), "="); ok && strings.TrimSpace(k) == "q" {
+			if parsedQ, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+				q = parsedQ
+			}
+		}
+		qValues[encoding] = q
+	}
+
+	selectedEncoding := ""
+	selectedQ := 0.0
+	for _, encoding := range opts.Priorities {
+		if _, ok := opts.Encoders[encoding]; !ok {
+			continue
+		}
+
+		q, ok := qValues[encoding]
+		if !ok {
+			q, ok = qValues["*"]
+		}
+
+		if ok && q > selectedQ {
+			selectedEncoding = encoding
+			selectedQ = q
+		}
+	}
+
+	return selectedEncoding
+}
+
+func isCompressibleContentType(contentType string, allowedContentTypes []string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	for _, allowedContentType := range allowedContentTypes {
+		if strings.HasSuffix(allowedContentType, "/*") {
+			if strings.HasPrefix(mediaType, strings.TrimSuffix(allowedContentType, "*")) {
+				return true
+			}
+		} else if mediaType == allowedContentType {
+			return true
+		}
+	}
+
+	return false
+}
+
+type compressWriter struct {
+	http.ResponseWriter
+	opts          CompressionOptions
+	encoding      string
+	encoder       io.WriteCloser
+	buf           []byte
+	statusCode    int
+	isDecided     bool
+	isWroteHeader bool
+	isClosed      bool
+}
+
+func (w *compressWriter) WriteHeader(statusCode int) {
+	if w.isWroteHeader {
+		return
+	}
+	w.isWroteHeader = true
+	w.statusCode = statusCode
+
+	// no body responses
+	if statusCode < http.StatusOK ||
+		statusCode == http.StatusNoContent ||
+		statusCode == http.StatusNotModified {
+		w.decide(false)
+	}
+}
+
+func (w *compressWriter) Write(b []byte) (int, error) {
+	if !w.isWroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if w.isDecided {
+		if w.encoder != nil {
+			return w.encoder.Write(b)
+		}
+		return w.ResponseWriter.Write(b)
+	}
+
+	// buffer until reach min size
+	// to decide whether compress or not
+	w.buf = append(w.buf, b...)
+	if len(w.buf) >= w.opts.MinSize {
+		if err := w.decide(true); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(b), nil
+}
+
+func (w *compressWriter) Flush() {
+	if !w.isDecided {
+		if !w.isWroteHeader {
+			w.WriteHeader(http.StatusOK)
+		}
+		w.decide(len(w.buf) >= w.opts.MinSize)
+	}
+
+	if flusher, ok := w.encoder.(interface{ Flush() error }); ok {
+		flusher.Flush()
+	}
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+func (w *compressWriter) Close() error {
+	if w.isClosed {
+		return nil
+	}
+	w.isClosed = true
+
+	if !w.isDecided {
+		if !w.isWroteHeader {
+
+			// nothing was written
+			return nil
+		}
+		w.decide(len(w.buf) >= w.opts.MinSize)
+	}
+
+	if w.encoder != nil {
+		return w.encoder.Close()
+	}
+
+	return nil
+}
+
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *compressWriter) decide(isSizeSatisfied bool) error {
+	w.isDecided = true
+	responseHeader := w.ResponseWriter.Header()
+
+	if isSizeSatisfied && w.shouldCompress() {
+		encoder, err := w.opts.Encoders[w.encoding](w.ResponseWriter, w.opts.Level)
+		if err == nil {
+			w.encoder = encoder
+			responseHeader.Set("Content-Encoding", w.encoding)
+			responseHeader.Del("Content-Length")
+			responseHeader.Del("Accept-Ranges")
+		}
+	}
+
+	w.ResponseWriter.WriteHeader(w.statusCode)
+
+	buf := w.buf
+	w.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+
+	if w.encoder != nil {
+		_, err := w.encoder.Write(buf)
+		return err
+	}
+
+	_, err := w.ResponseWriter.Write(buf)
+	return err
+}
+
+func (w *compressWriter) shouldCompress() bool {
+	responseHeader := w.ResponseWriter.Header()
+
+	// already compressed
+	// or partial content
+	if responseHeader.Get("Content-Encoding") != "" ||
+		responseHeader.Get("Content-Range") != "" ||
+		w.statusCode == http.StatusPartialContent {
+		return false
+	}
+
+	contentType := responseHeader.Get("Content-Type")
+	if contentType == "" {
+		contentType = http.DetectContentType(w.buf)
+		responseHeader.Set("Content-Type", contentType)
+	}
+
+	return isCompressibleContentType(contentType, w.opts.ContentTypes)
+}
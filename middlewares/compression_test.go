@@ -0,0 +1,151 @@
+package middlewares
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dangduoc08/gogo/utils"
+)
+
+func TestCompressionMinSize(t *testing.T) {
+	compression := Compression(CompressionOptions{MinSize: 100})
+
+	cases := map[string]string{
+		strings.Repeat("a", 99):  "",
+		strings.Repeat("a", 100): EncodingGzip,
+	}
+
+	for body, expectedEncoding := range cases {
+		r := httptest.NewRequest(http.MethodGet, "/articles", nil)
+		r.Header.Set("Accept-Encoding", "gzip, deflate;q=0.5")
+		c, recorder := newMiddlewareContext(r)
+		c.Next = func() {
+			c.ResponseWriter.Header().Set("Content-Type", "text/plain")
+			c.ResponseWriter.Write([]byte(body))
+		}
+		compression(c)
+		c.Reset()
+
+		if vary := recorder.Header().Get("Vary"); vary != "Accept-Encoding" {
+			t.Errorf(utils.ErrorMessage(vary, "Accept-Encoding", "Vary header should be set"))
+		}
+
+		contentEncoding := recorder.Header().Get("Content-Encoding")
+		if contentEncoding != expectedEncoding {
+			t.Errorf(utils.ErrorMessage(contentEncoding, expectedEncoding, fmt.Sprintf("body of %v bytes should be compressed by MinSize", len(body))))
+		}
+
+		actual := recorder.Body.String()
+		if contentEncoding == EncodingGzip {
+			gzipReader, _ := gzip.NewReader(recorder.Body)
+			decodedBody, _ := io.ReadAll(gzipReader)
+			actual = string(decodedBody)
+		}
+
+		if actual != body {
+			t.Errorf(utils.ErrorMessage(len(actual), len(body), "body should be equal"))
+		}
+	}
+}
+
+func TestCompressionUnacceptedEncoding(t *testing.T) {
+	compression := Compression(CompressionOptions{MinSize: 1})
+
+	r := httptest.NewRequest(http.MethodGet, "/articles", nil)
+	r.Header.Set("Accept-Encoding", "gzip;q=0, deflate;q=0")
+	c, recorder := newMiddlewareContext(r)
+	c.Next = func() {
+		c.ResponseWriter.Header().Set("Content-Type", "text/plain")
+		c.ResponseWriter.Write([]byte("article"))
+	}
+	compression(c)
+	c.Reset()
+
+	if contentEncoding := recorder.Header().Get("Content-Encoding"); contentEncoding != "" {
+		t.Errorf(utils.ErrorMessage(contentEncoding, "", "refused encodings should not be used"))
+	}
+
+	if vary := recorder.Header().Get("Vary"); vary != "Accept-Encoding" {
+		t.Errorf(utils.ErrorMessage(vary, "Accept-Encoding", "Vary header should be set"))
+	}
+}
+
+func newGzipRequest(body string) *http.Request {
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	gzipWriter.Write([]byte(body))
+	gzipWriter.Close()
+
+	r := httptest.NewRequest(http.MethodPost, "/articles", &buf)
+	r.Header.Set("Content-Encoding", "gzip")
+
+	return r
+}
+
+func TestCompressionDecompressRequest(t *testing.T) {
+	compression := Compression()
+
+	c, _ := newMiddlewareContext(newGzipRequest(`{"title":"gogo"}`))
+	decodedBody, contentEncoding := "", ""
+	c.Next = func() {
+		b, _ := io.ReadAll(c.Request.Body)
+		decodedBody = string(b)
+		contentEncoding = c.Request.Header.Get("Content-Encoding")
+	}
+	compression(c)
+	c.Reset()
+
+	if decodedBody != `{"title":"gogo"}` {
+		t.Errorf(utils.ErrorMessage(decodedBody, `{"title":"gogo"}`, "gzip request body should be decompressed"))
+	}
+
+	if contentEncoding != "" {
+		t.Errorf(utils.ErrorMessage(contentEncoding, "", "Content-Encoding should be removed once decompressed"))
+	}
+}
+
+func TestCompressionUnsupportedRequestEncoding(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/articles", strings.NewReader("article"))
+	r.Header.Set("Content-Encoding", "compress")
+	c, recorder := newMiddlewareContext(r)
+
+	if isNext, _ := runMiddleware(Compression(), c); isNext {
+		t.Errorf(utils.ErrorMessage(isNext, false, "unsupported request encoding should not be passed to handler"))
+	}
+
+	if recorder.Code != http.StatusUnsupportedMediaType {
+		t.Errorf(utils.ErrorMessage(recorder.Code, http.StatusUnsupportedMediaType, "status should be equal"))
+	}
+}
+
+func TestCompressionMaxDecompressedSize(t *testing.T) {
+	compression := Compression(CompressionOptions{MaxDecompressedSize: 100})
+
+	cases := map[int]bool{
+		100: false,
+		101: true,
+	}
+
+	for size, isExceeded := range cases {
+		c, _ := newMiddlewareContext(newGzipRequest(strings.Repeat("a", size)))
+
+		var err error
+		c.Next = func() {
+			_, err = io.ReadAll(c.Request.Body)
+		}
+		compression(c)
+		c.Reset()
+
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) != isExceeded {
+			t.Errorf(utils.ErrorMessage(err, isExceeded, fmt.Sprintf("decompressed body of %v bytes should be limited by MaxDecompressedSize", size)))
+		}
+	}
+}
@@ -1,13 +1,181 @@
 package middlewares
 
-import "github.com/dangduoc08/gogo/ctx"
+import (
+	"errors"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dangduoc08/gogo/ctx"
+)
+
+type CORSOptions struct {
+
+	// origins are allowed when matched any of
+	// Origins, OriginRegexps or OriginFunc.
+	// "*" allows all origins,
+	// it can't be used with IsCredentials
+	Origins       []string
+	OriginRegexps []*regexp.Regexp
+	OriginFunc    func(origin string) bool
+
+	Methods []string
+
+	// request headers are reflected
+	// from Access-Control-Request-Headers if empty
+	Headers        []string
+	ExposedHeaders []string
+	IsCredentials  bool
+	MaxAge         time.Duration
+}
+
+func loadCORSOptions(opts []CORSOptions) CORSOptions {
+	corsOptions := CORSOptions{}
+	if len(opts) > 0 {
+		corsOptions = opts[0]
+	}
+
+	if len(corsOptions.Origins) == 0 &&
+		len(corsOptions.OriginRegexps) == 0 &&
+		corsOptions.OriginFunc == nil {
+		corsOptions.Origins = []string{"*"}
+	}
+
+	// reflecting any origin with credentials
+	// would let every site send credentialed requests
+	if corsOptions.IsCredentials && isAllowAllOrigins(corsOptions.Origins) {
+		panic(errors.New("cors: credentials require explicit Origins, OriginRegexps or OriginFunc"))
+	}
+
+	if len(corsOptions.Methods) == 0 {
+		corsOptions.Methods = []string{
+			http.MethodGet,
+			http.MethodHead,
+			http.MethodPut,
+			http.MethodPatch,
+			http.MethodPost,
+			http.MethodDelete,
+		}
+	}
+
+	return corsOptions
+}
+
+// CORS sets Access-Control-* headers for allowed origins
+// and answers preflight requests
+// even there is no OPTIONS route
+func CORS(opts ...CORSOptions) func(*ctx.Context) {
+	corsOptions := loadCORSOptions(opts)
+	isAllowAllOrigins := isAllowAllOrigins(corsOptions.Origins)
+
+	allowedMethods := strings.Join(corsOptions.Methods, ", ")
+	allowedHeaders := strings.Join(corsOptions.Headers, ", ")
+	exposedHeaders := strings.Join(corsOptions.ExposedHeaders, ", ")
+	maxAge := ""
+	if corsOptions.MaxAge > 0 {
+		maxAge = strconv.Itoa(int(corsOptions.MaxAge.Seconds()))
+	}
 
-func CORS() func(*ctx.Context) {
 	return func(c *ctx.Context) {
-		c.ResponseWriter.Header().Set("Access-Control-Allow-Origin", "*")
-		c.ResponseWriter.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
-		c.ResponseWriter.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		if c.GetType() != ctx.HTTPType {
+			c.Next()
+			return
+		}
+
+		responseHeader := c.ResponseWriter.Header()
+		origin := c.Request.Header.Get("Origin")
+		isPreflight := c.Request.Method == http.MethodOptions &&
+			c.Request.Header.Get("Access-Control-Request-Method") != ""
+
+		// response is different per origin
+		// unless wildcard origin is responded
+		if !isAllowAllOrigins {
+			responseHeader.Add("Vary", "Origin")
+		}
 
-		c.Next()
+		if origin == "" {
+			c.Next()
+			return
+		}
+
+		if !isAllowAllOrigins && !isAllowedOrigin(origin, corsOptions) {
+
+			// without CORS headers
+			// browsers will block the request
+			if isPreflight {
+				c.Status(http.StatusNoContent)
+				c.ResponseWriter.WriteHeader(c.Code)
+				c.Event.Emit(ctx.REQUEST_FINISHED, c)
+				return
+			}
+
+			c.Next()
+			return
+		}
+
+		if isAllowAllOrigins {
+			responseHeader.Set("Access-Control-Allow-Origin", "*")
+		} else {
+			responseHeader.Set("Access-Control-Allow-Origin", origin)
+		}
+
+		if corsOptions.IsCredentials {
+			responseHeader.Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if !isPreflight {
+			if exposedHeaders != "" {
+				responseHeader.Set("Access-Control-Expose-Headers", exposedHeaders)
+			}
+
+			c.Next()
+			return
+		}
+
+		responseHeader.Add("Vary", "Access-Control-Request-Method")
+		responseHeader.Set("Access-Control-Allow-Methods", allowedMethods)
+
+		if allowedHeaders != "" {
+			responseHeader.Set("Access-Control-Allow-Headers", allowedHeaders)
+		} else if requestHeaders := c.Request.Header.Get("Access-Control-Request-Headers"); requestHeaders != "" {
+			responseHeader.Add("Vary", "Access-Control-Request-Headers")
+			responseHeader.Set("Access-Control-Allow-Headers", requestHeaders)
+		}
+
+		if maxAge != "" {
+			responseHeader.Set("Access-Control-Max-Age", maxAge)
+		}
+
+		c.Status(http.StatusNoContent)
+		c.ResponseWriter.WriteHeader(c.Code)
+		c.Event.Emit(ctx.REQUEST_FINISHED, c)
 	}
 }
+
+func isAllowAllOrigins(origins []string) bool {
+	for _, origin := range origins {
+		if origin == "*" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isAllowedOrigin(origin string, opts CORSOptions) bool {
+	for _, allowedOrigin := range opts.Origins {
+		if strings.EqualFold(allowedOrigin, origin) {
+			return true
+		}
+	}
+
+	for _, originRegexp := range opts.OriginRegexps {
+		if originRegexp.MatchString(origin) {
+			return true
+		}
+	}
+
+	return opts.OriginFunc != nil && opts.OriginFunc(origin)
+}
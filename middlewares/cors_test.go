@@ -0,0 +1,128 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/dangduoc08/gogo/utils"
+)
+
+func newPreflightRequest(origin string) *http.Request {
+	r := httptest.NewRequest(http.MethodOptions, "/articles", nil)
+	r.Header.Set("Origin", origin)
+	r.Header.Set("Access-Control-Request-Method", http.MethodPut)
+	r.Header.Set("Access-Control-Request-Headers", "X-Requested-With")
+
+	return r
+}
+
+func TestCORSPreflight(t *testing.T) {
+	cors := CORS(CORSOptions{
+		OriginRegexps: []*regexp.Regexp{regexp.MustCompile(`^https://[a-z]+\.gogo\.dev$`)},
+		IsCredentials: true,
+		MaxAge:        time.Hour,
+	})
+
+	c, recorder := newMiddlewareContext(newPreflightRequest("https://app.gogo.dev"))
+	if isNext, _ := runMiddleware(cors, c); isNext {
+		t.Errorf(utils.ErrorMessage(isNext, false, "preflight should be answered by middleware"))
+	}
+
+	if recorder.Code != http.StatusNoContent {
+		t.Errorf(utils.ErrorMessage(recorder.Code, http.StatusNoContent, "status should be equal"))
+	}
+
+	cases := map[string]string{
+		"Access-Control-Allow-Origin":      "https://app.gogo.dev",
+		"Access-Control-Allow-Credentials": "true",
+		"Access-Control-Allow-Methods":     "GET, HEAD, PUT, PATCH, POST, DELETE",
+		"Access-Control-Allow-Headers":     "X-Requested-With",
+		"Access-Control-Max-Age":           "3600",
+	}
+
+	for k, expected := range cases {
+		if actual := recorder.Header().Get(k); actual != expected {
+			t.Errorf(utils.ErrorMessage(actual, expected, k+" header should be equal"))
+		}
+	}
+
+	expectedVary := []string{"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"}
+	if vary := recorder.Header().Values("Vary"); len(vary) != len(expectedVary) {
+		t.Errorf(utils.ErrorMessage(vary, expectedVary, "Vary header should be equal"))
+	}
+
+	// disallowed origin is answered
+	// without CORS headers
+	c, recorder = newMiddlewareContext(newPreflightRequest("https://gogo.evil"))
+	runMiddleware(cors, c)
+
+	if allowOrigin := recorder.Header().Get("Access-Control-Allow-Origin"); allowOrigin != "" {
+		t.Errorf(utils.ErrorMessage(allowOrigin, "", "disallowed origin should not be allowed"))
+	}
+
+	if recorder.Code != http.StatusNoContent {
+		t.Errorf(utils.ErrorMessage(recorder.Code, http.StatusNoContent, "status should be equal"))
+	}
+}
+
+func TestCORSSimpleRequest(t *testing.T) {
+	cors := CORS(CORSOptions{
+		ExposedHeaders: []string{"X-Total-Count"},
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/articles", nil)
+	r.Header.Set("Origin", "https://app.gogo.dev")
+	c, recorder := newMiddlewareContext(r)
+
+	if isNext, _ := runMiddleware(cors, c); !isNext {
+		t.Errorf(utils.ErrorMessage(isNext, true, "simple request should be passed to handler"))
+	}
+
+	if allowOrigin := recorder.Header().Get("Access-Control-Allow-Origin"); allowOrigin != "*" {
+		t.Errorf(utils.ErrorMessage(allowOrigin, "*", "wildcard origin should be responded"))
+	}
+
+	if exposedHeaders := recorder.Header().Get("Access-Control-Expose-Headers"); exposedHeaders != "X-Total-Count" {
+		t.Errorf(utils.ErrorMessage(exposedHeaders, "X-Total-Count", "exposed headers should be equal"))
+	}
+}
+
+func TestCORSWildcardOriginWithCredentials(t *testing.T) {
+	cases := []CORSOptions{
+		{IsCredentials: true},
+		{Origins: []string{"https://app.gogo.dev", "*"}, IsCredentials: true},
+	}
+
+	for _, opts := range cases {
+		func() {
+			defer func() {
+				if rec := recover(); rec == nil {
+					t.Errorf(utils.ErrorMessage(rec, "error", "wildcard origin with credentials should panic"))
+				}
+			}()
+
+			CORS(opts)
+		}()
+	}
+
+	// credentials are allowed
+	// for explicit origins only
+	cors := CORS(CORSOptions{
+		Origins:       []string{"https://app.gogo.dev"},
+		IsCredentials: true,
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/articles", nil)
+	r.Header.Set("Origin", "https://gogo.evil")
+	c, recorder := newMiddlewareContext(r)
+	runMiddleware(cors, c)
+
+	for _, k := range []string{"Access-Control-Allow-Origin", "Access-Control-Allow-Credentials"} {
+		if actual := recorder.Header().Get(k); actual != "" {
+			t.Errorf(utils.ErrorMessage(actual, "", k+" should not be set for disallowed origin"))
+		}
+	}
+}
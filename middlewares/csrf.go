@@ -0,0 +1,235 @@
+package middlewares
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"github.com/dangduoc08/gogo/ctx"
+	"github.com/dangduoc08/gogo/exception"
+)
+
+// CSRFStore keeps token of client
+// for synchronizer token pattern, e.g. in session
+type CSRFStore interface {
+	Get(c *ctx.Context) string
+	Set(c *ctx.Context, token string)
+}
+
+type CSRFOptions struct {
+
+	// tokens are verified against Store if it was set,
+	// otherwise against cookie (double-submit cookie)
+	Store CSRFStore
+
+	CookieName     string
+	CookiePath     string
+	CookieDomain   string
+	CookieSameSite http.SameSite
+	IsCookieSecure bool
+
+	// token is submitted by header
+	// or form field,
+	// multipart field must precede files
+	HeaderName string
+	FieldName  string
+
+	// safe methods are exempted by default
+	IgnoredMethods []string
+
+	// exempted paths,
+	// trailing * matches any suffix
+	IgnoredRoutes []string
+}
+
+func loadCSRFOptions(opts []CSRFOptions) CSRFOptions {
+	csrfOptions := CSRFOptions{}
+	if len(opts) > 0 {
+		csrfOptions = opts[0]
+	}
+
+	if csrfOptions.CookieName == "" {
+		csrfOptions.CookieName = "_csrf"
+	}
+
+	if csrfOptions.CookiePath == "" {
+		csrfOptions.CookiePath = "/"
+	}
+
+	if csrfOptions.CookieSameSite == 0 {
+		csrfOptions.CookieSameSite = http.SameSiteLaxMode
+	}
+
+	if csrfOptions.HeaderName == "" {
+		csrfOptions.HeaderName = "X-CSRF-Token"
+	}
+
+	if csrfOptions.FieldName == "" {
+		csrfOptions.FieldName = "_csrf"
+	}
+
+	if len(csrfOptions.IgnoredMethods) == 0 {
+		csrfOptions.IgnoredMethods = []string{
+			http.MethodGet,
+			http.MethodHead,
+			http.MethodOptions,
+			http.MethodTrace,
+		}
+	}
+
+	return csrfOptions
+}
+
+// CSRF issues token for every request,
+// token can be injected into handlers by ctx.CSRFToken
+// and must be submitted back by unsafe requests
+func CSRF(opts ...CSRFOptions) func(*ctx.Context) {
+	csrfOptions := loadCSRFOptions(opts)
+
+	return func(c *ctx.Context) {
+		if c.GetType() != ctx.HTTPType {
+			c.Next()
+			return
+		}
+
+		token := ""
+		if csrfOptions.Store != nil {
+			token = csrfOptions.Store.Get(c)
+		} else if cookie, err := c.Request.Cookie(csrfOptions.CookieName); err == nil {
+			token = cookie.Value
+		}
+
+		isIssued := false
+		if token == "" {
+			token = genCSRFToken()
+			isIssued = true
+
+			if csrfOptions.Store != nil {
+				csrfOptions.Store.Set(c, token)
+			} else {
+
+				// readable by scripts
+				// to be submitted by SPAs
+				http.SetCookie(c.ResponseWriter, &http.Cookie{
+					Name:     csrfOptions.CookieName,
+					Value:    token,
+					Path:     csrfOptions.CookiePath,
+					Domain:   csrfOptions.CookieDomain,
+					SameSite: csrfOptions.CookieSameSite,
+					Secure:   csrfOptions.IsCookieSecure,
+				})
+			}
+		}
+		c.SetCSRFToken(token)
+
+		if isIgnoredCSRF(c, csrfOptions) {
+			c.Next()
+			return
+		}
+
+		submittedToken := c.Request.Header.Get(csrfOptions.HeaderName)
+		if submittedToken == "" {
+			submittedToken = getCSRFFieldValue(c, csrfOptions.FieldName)
+		}
+
+		if isIssued ||
+			submittedToken == "" ||
+			subtle.ConstantTimeCompare([]byte(submittedToken), []byte(token)) != 1 {
+			panic(exception.ForbiddenException("Invalid CSRF token"))
+		}
+
+		c.Next()
+	}
+}
+
+// csrfPeekSize limits bytes of multipart body
+// which are read to find token field
+const csrfPeekSize = 64 << 10
+
+// getCSRFFieldValue reads token field of form body,
+// multipart body is peeked up to csrfPeekSize
+// then replayed to not be buffered before multipart limits apply
+func getCSRFFieldValue(c *ctx.Context, fieldName string) string {
+	mediaType, params, err := mime.ParseMediaType(c.Request.Header.Get("Content-Type"))
+	if err != nil {
+		return ""
+	}
+
+	if mediaType == "application/x-www-form-urlencoded" {
+		return c.Request.PostFormValue(fieldName)
+	}
+
+	if mediaType != "multipart/form-data" || params["boundary"] == "" || c.Request.Body == nil {
+		return ""
+	}
+
+	body := c.Request.Body
+	peekedBody := &bytes.Buffer{}
+	defer func() {
+		c.Request.Body = struct {
+			io.Reader
+			io.Closer
+		}{
+			Reader: io.MultiReader(peekedBody, body),
+			Closer: body,
+		}
+	}()
+
+	reader := multipart.NewReader(io.TeeReader(io.LimitReader(body, csrfPeekSize), peekedBody), params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			return ""
+		}
+
+		// files aren't read,
+		// token must be submitted before them
+		if part.FileName() != "" {
+			return ""
+		}
+
+		if part.FormName() == fieldName {
+			value, err := io.ReadAll(part)
+			if err != nil {
+				return ""
+			}
+
+			return string(value)
+		}
+	}
+}
+
+func genCSRFToken() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func isIgnoredCSRF(c *ctx.Context, opts CSRFOptions) bool {
+	for _, method := range opts.IgnoredMethods {
+		if c.Request.Method == method {
+			return true
+		}
+	}
+
+	for _, route := range opts.IgnoredRoutes {
+		if strings.HasSuffix(route, "*") {
+			if strings.HasPrefix(c.Request.URL.Path, strings.TrimSuffix(route, "*")) {
+				return true
+			}
+		} else if c.Request.URL.Path == route {
+			return true
+		}
+	}
+
+	return false
+}
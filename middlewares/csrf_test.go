@@ -0,0 +1,128 @@
+package middlewares
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dangduoc08/gogo/ctx"
+	"github.com/dangduoc08/gogo/exception"
+	"github.com/dangduoc08/gogo/utils"
+)
+
+func newMiddlewareContext(r *http.Request) (*ctx.Context, *httptest.ResponseRecorder) {
+	c := ctx.NewContext()
+	c.Event = ctx.NewEvent()
+	c.Request = r
+	c.SetType(ctx.HTTPType)
+	recorder := httptest.NewRecorder()
+	c.ResponseWriter = recorder
+
+	return c, recorder
+}
+
+// runMiddleware reports whether middleware called next
+// and returns recovered exception
+func runMiddleware(middleware func(*ctx.Context), c *ctx.Context) (isNext bool, rec any) {
+	c.Next = func() {
+		isNext = true
+	}
+
+	defer func() {
+		rec = recover()
+	}()
+	middleware(c)
+
+	return isNext, rec
+}
+
+func newCSRFRequest(body io.Reader, contentType string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/posts", body)
+	r.AddCookie(&http.Cookie{Name: "_csrf", Value: "token"})
+	if contentType != "" {
+		r.Header.Set("Content-Type", contentType)
+	}
+
+	return r
+}
+
+func newCSRFMultipartBody(fields [][]string, fileSize int) (*bytes.Buffer, string) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for _, field := range fields {
+		if field[0] == "file" {
+			fileWriter, _ := writer.CreateFormFile("file", "upload.bin")
+			fileWriter.Write(bytes.Repeat([]byte("a"), fileSize))
+			continue
+		}
+		writer.WriteField(field[0], field[1])
+	}
+	writer.Close()
+
+	return body, writer.FormDataContentType()
+}
+
+func TestCSRFRejection(t *testing.T) {
+	csrf := CSRF()
+
+	c, _ := newMiddlewareContext(newCSRFRequest(nil, ""))
+	isNext, rec := runMiddleware(csrf, c)
+	if httpException, ok := rec.(exception.HTTPException); isNext || !ok || httpException.GetCode() != exception.ForbiddenException("").GetCode() {
+		t.Errorf(utils.ErrorMessage(rec, "ForbiddenException", "missing token should be rejected"))
+	}
+
+	c, _ = newMiddlewareContext(newCSRFRequest(nil, ""))
+	c.Request.Header.Set("X-CSRF-Token", "forged")
+	if isNext, _ := runMiddleware(csrf, c); isNext {
+		t.Errorf(utils.ErrorMessage(isNext, false, "wrong token should be rejected"))
+	}
+
+	c, _ = newMiddlewareContext(newCSRFRequest(nil, ""))
+	c.Request.Header.Set("X-CSRF-Token", "token")
+	if isNext, rec := runMiddleware(csrf, c); !isNext || rec != nil {
+		t.Errorf(utils.ErrorMessage(rec, nil, "header token should be accepted"))
+	}
+
+	c, _ = newMiddlewareContext(newCSRFRequest(strings.NewReader("_csrf=token"), "application/x-www-form-urlencoded"))
+	if isNext, rec := runMiddleware(csrf, c); !isNext || rec != nil {
+		t.Errorf(utils.ErrorMessage(rec, nil, "form token should be accepted"))
+	}
+
+	c, recorder := newMiddlewareContext(httptest.NewRequest(http.MethodGet, "/posts", nil))
+	if isNext, _ := runMiddleware(csrf, c); !isNext || len(recorder.Result().Cookies()) != 1 {
+		t.Errorf(utils.ErrorMessage(isNext, true, "safe method should be passed with issued token"))
+	}
+}
+
+func TestCSRFMultipart(t *testing.T) {
+	csrf := CSRF()
+	fileSize := 1 << 20
+
+	body, contentType := newCSRFMultipartBody([][]string{{"_csrf", "token"}, {"file"}}, fileSize)
+	bodySize := body.Len()
+	c, _ := newMiddlewareContext(newCSRFRequest(body, contentType))
+
+	if isNext, rec := runMiddleware(csrf, c); !isNext || rec != nil {
+		t.Fatalf(utils.ErrorMessage(rec, nil, "multipart token should be accepted"))
+	}
+
+	if c.Request.MultipartForm != nil {
+		t.Errorf(utils.ErrorMessage(c.Request.MultipartForm, nil, "multipart body should not be parsed"))
+	}
+
+	if replayedBody, _ := io.ReadAll(c.Request.Body); len(replayedBody) != bodySize {
+		t.Errorf(utils.ErrorMessage(len(replayedBody), bodySize, "multipart body should be replayed"))
+	}
+
+	// file precedes token,
+	// it isn't read to find token
+	body, contentType = newCSRFMultipartBody([][]string{{"file"}, {"_csrf", "token"}}, fileSize)
+	c, _ = newMiddlewareContext(newCSRFRequest(body, contentType))
+	if isNext, rec := runMiddleware(csrf, c); isNext || rec == nil {
+		t.Errorf(utils.ErrorMessage(rec, "ForbiddenException", "token after file should be rejected"))
+	}
+}
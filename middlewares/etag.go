@@ -0,0 +1,247 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dangduoc08/gogo/ctx"
+	"github.com/dangduoc08/gogo/exception"
+)
+
+type ETagOptions struct {
+	IsWeak bool
+
+	// responses larger than this size
+	// are sent without ETag
+	MaxBufferSize int
+}
+
+func loadETagOptions(opts []ETagOptions) ETagOptions {
+	etagOptions := ETagOptions{}
+	if len(opts) > 0 {
+		etagOptions = opts[0]
+	}
+
+	if etagOptions.MaxBufferSize == 0 {
+		etagOptions.MaxBufferSize = 1 << 20
+	}
+
+	return etagOptions
+}
+
+// ETag generates ETag for GET and HEAD responses
+// and answers If-None-Match, If-Match, If-Unmodified-Since
+// with 304 or PreconditionFailedException.
+// buffered responses are decided before REQUEST_FINISHED is emitted
+func ETag(opts ...ETagOptions) func(*ctx.Context) {
+	etagOptions := loadETagOptions(opts)
+
+	return func(c *ctx.Context) {
+		if c.GetType() != ctx.HTTPType ||
+			(c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead) {
+			c.Next()
+			return
+		}
+
+		etagWriter := &etagWriter{
+			ResponseWriter: c.ResponseWriter,
+			c:              c,
+			opts:           etagOptions,
+		}
+		c.ResponseWriter = etagWriter
+		c.BeforeFinish(etagWriter.decide)
+
+		// responses which were written
+		// without REQUEST_FINISHED
+		c.Defer(func() {
+			etagWriter.Close()
+		})
+
+		c.Next()
+	}
+}
+
+const (
+	etagUndecided = iota
+	etagBuffering
+	etagPassthrough
+	etagDiscarding
+)
+
+type etagWriter struct {
+	http.ResponseWriter
+	c          *ctx.Context
+	opts       ETagOptions
+	buf        []byte
+	statusCode int
+	mode       int
+}
+
+func (w *etagWriter) WriteHeader(statusCode int) {
+	if w.mode != etagUndecided {
+		return
+	}
+	w.statusCode = statusCode
+	responseHeader := w.ResponseWriter.Header()
+
+	if statusCode != http.StatusOK {
+		w.passthrough()
+		return
+	}
+
+	// ETag was declared by handler
+	// or static file
+	// then no need buffering
+	etag := responseHeader.Get("ETag")
+	if etag == "" &&
+		responseHeader.Get("Last-Modified") != "" &&
+		responseHeader.Get("Content-Length") != "" &&
+		responseHeader.Get("Content-Encoding") == "" {
+		etag = genFileETag(responseHeader)
+		responseHeader.Set("ETag", etag)
+	}
+
+	if etag != "" {
+		if status := ctx.CheckPreconditions(w.c.Request, etag, getLastModified(responseHeader)); status != 0 {
+			w.reject(status, true)
+			return
+		}
+		w.passthrough()
+		return
+	}
+
+	if strings.HasPrefix(responseHeader.Get("Content-Type"), "text/event-stream") {
+		w.passthrough()
+		return
+	}
+
+	w.mode = etagBuffering
+}
+
+func (w *etagWriter) Write(b []byte) (int, error) {
+	if w.mode == etagUndecided {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	switch w.mode {
+	case etagDiscarding:
+		return len(b), nil
+	case etagBuffering:
+		w.buf = append(w.buf, b...)
+		if len(w.buf) > w.opts.MaxBufferSize {
+			if err := w.passthrough(); err != nil {
+				return 0, err
+			}
+		}
+		return len(b), nil
+	}
+
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *etagWriter) Flush() {
+	if w.mode == etagBuffering {
+		w.passthrough()
+	}
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+func (w *etagWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// decide runs before REQUEST_FINISHED,
+// exceptions are still handled by exception filters
+func (w *etagWriter) decide() {
+	w.finalize(true)
+}
+
+func (w *etagWriter) Close() {
+	w.finalize(false)
+}
+
+func (w *etagWriter) finalize(isRaisable bool) {
+	if w.mode != etagBuffering {
+		return
+	}
+
+	responseHeader := w.ResponseWriter.Header()
+	etag := ctx.GenerateETag(w.buf, w.opts.IsWeak)
+	responseHeader.Set("ETag", etag)
+
+	if status := ctx.CheckPreconditions(w.c.Request, etag, getLastModified(responseHeader)); status != 0 {
+		w.reject(status, isRaisable)
+		return
+	}
+
+	w.passthrough()
+}
+
+func (w *etagWriter) passthrough() error {
+	w.mode = etagPassthrough
+	w.ResponseWriter.WriteHeader(w.statusCode)
+
+	buf := w.buf
+	w.buf = nil
+	if len(buf) > 0 {
+		_, err := w.ResponseWriter.Write(buf)
+		return err
+	}
+
+	return nil
+}
+
+func (w *etagWriter) reject(status int, isRaisable bool) {
+	w.buf = nil
+	responseHeader := w.ResponseWriter.Header()
+	responseHeader.Del("Content-Length")
+	responseHeader.Del("Content-Encoding")
+
+	if status == http.StatusNotModified {
+		w.mode = etagDiscarding
+		w.c.Status(status)
+		responseHeader.Del("Content-Type")
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+
+	preconditionFailedException := exception.PreconditionFailedException("Precondition failed")
+
+	// nothing was sent yet,
+	// exception filters write response
+	// then it's passed through
+	if isRaisable {
+		w.mode = etagUndecided
+		panic(preconditionFailedException)
+	}
+
+	w.mode = etagDiscarding
+	w.c.Status(status)
+	responseHeader.Set("Content-Type", "application/json")
+	w.ResponseWriter.WriteHeader(status)
+	json.NewEncoder(w.ResponseWriter).Encode(ctx.Map{
+		"code":    preconditionFailedException.GetCode(),
+		"error":   preconditionFailedException.Error(),
+		"message": preconditionFailedException.GetResponse(),
+	})
+}
+
+func genFileETag(responseHeader http.Header) string {
+	return fmt.Sprintf(
+		`W/"%x-%s"`,
+		getLastModified(responseHeader).Unix(),
+		responseHeader.Get("Content-Length"),
+	)
+}
+
+func getLastModified(responseHeader http.Header) time.Time {
+	lastModified, err := http.ParseTime(responseHeader.Get("Last-Modified"))
+	if err != nil {
+		return time.Time{}
+	}
+
+	return lastModified
+}
@@ -0,0 +1,92 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dangduoc08/gogo/ctx"
+	"github.com/dangduoc08/gogo/exception"
+	"github.com/dangduoc08/gogo/utils"
+)
+
+func newETagContext(header map[string]string) (*ctx.Context, *httptest.ResponseRecorder, *int) {
+	r := httptest.NewRequest(http.MethodGet, "/articles", nil)
+	for k, v := range header {
+		r.Header.Set(k, v)
+	}
+
+	c, recorder := newMiddlewareContext(r)
+	c.Next = func() {
+		c.JSON(ctx.Map{"title": "gogo"})
+	}
+
+	finishedCode := 0
+	c.Event.On(ctx.REQUEST_FINISHED, func(args ...any) {
+		finishedCode = args[0].(*ctx.Context).Code
+	})
+
+	return c, recorder, &finishedCode
+}
+
+func TestETagNotModified(t *testing.T) {
+	etag := ETag()
+
+	c, recorder, _ := newETagContext(nil)
+	etag(c)
+	c.Reset()
+
+	etagValue := recorder.Header().Get("ETag")
+	if recorder.Code != http.StatusOK || etagValue == "" {
+		t.Fatalf(utils.ErrorMessage(etagValue, "ETag", "ETag should be generated"))
+	}
+
+	c, recorder, finishedCode := newETagContext(map[string]string{
+		"If-None-Match": etagValue,
+	})
+	etag(c)
+
+	if *finishedCode != http.StatusNotModified {
+		t.Errorf(utils.ErrorMessage(*finishedCode, http.StatusNotModified, "REQUEST_FINISHED should be emitted with final status"))
+	}
+	c.Reset()
+
+	if recorder.Code != http.StatusNotModified {
+		t.Errorf(utils.ErrorMessage(recorder.Code, http.StatusNotModified, "status should be equal"))
+	}
+
+	if recorder.Body.Len() != 0 {
+		t.Errorf(utils.ErrorMessage(recorder.Body.String(), "", "body should be empty"))
+	}
+}
+
+func TestETagPreconditionFailed(t *testing.T) {
+	c, recorder, finishedCode := newETagContext(map[string]string{
+		"If-Match": `"outdated"`,
+	})
+
+	var rec any
+	func() {
+		defer func() {
+			rec = recover()
+		}()
+		ETag()(c)
+	}()
+
+	httpException, ok := rec.(exception.HTTPException)
+	if code, _ := httpException.GetHTTPStatus(); !ok || code != http.StatusPreconditionFailed {
+		t.Fatalf(utils.ErrorMessage(rec, "PreconditionFailedException", "exception should be raised"))
+	}
+
+	if recorder.Body.Len() != 0 || *finishedCode != 0 {
+		t.Errorf(utils.ErrorMessage(recorder.Body.String(), "", "nothing should be sent before exception filters"))
+	}
+
+	// exception filters write response
+	c.Status(http.StatusPreconditionFailed).JSON(ctx.Map{"code": httpException.GetCode()})
+	c.Reset()
+
+	if recorder.Code != http.StatusPreconditionFailed || *finishedCode != http.StatusPreconditionFailed {
+		t.Errorf(utils.ErrorMessage(recorder.Code, http.StatusPreconditionFailed, "response of exception filters should be sent"))
+	}
+}
@@ -0,0 +1,23 @@
+package middlewares
+
+import (
+	"github.com/dangduoc08/gogo/ctx"
+)
+
+// Multipart limits uploads and chooses storage
+// of multipart bodies, bind it per handler through module middleware:
+// module.Middleware.Apply(middlewares.Multipart(opts), controller.CREATE_avatars)
+func Multipart(opts ...ctx.MultipartOptions) func(*ctx.Context) {
+	multipartOptions := ctx.MultipartOptions{}
+	if len(opts) > 0 {
+		multipartOptions = opts[0]
+	}
+
+	return func(c *ctx.Context) {
+		if c.GetType() == ctx.HTTPType {
+			c.SetMultipartOptions(multipartOptions)
+		}
+
+		c.Next()
+	}
+}
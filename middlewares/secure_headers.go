@@ -0,0 +1,179 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/dangduoc08/gogo/ctx"
+)
+
+const CSPNonce = "{nonce}"
+
+// empty values fallback to defaults,
+// use DisabledHeaders to omit headers
+type SecureHeadersOptions struct {
+
+	// CSPNonce placeholder will be replaced
+	// by nonce of current request
+	ContentSecurityPolicy     string
+	IsCSPReportOnly           bool
+	StrictTransportSecurity   string
+	XFrameOptions             string
+	XContentTypeOptions       string
+	ReferrerPolicy            string
+	PermissionsPolicy         string
+	CrossOriginOpenerPolicy   string
+	CrossOriginResourcePolicy string
+	CrossOriginEmbedderPolicy string
+	DisabledHeaders           []string
+}
+
+var defaultContentSecurityPolicy = strings.Join([]string{
+	"default-src 'self'",
+	"base-uri 'self'",
+	"font-src 'self' https: data:",
+	"form-action 'self'",
+	"frame-ancestors 'self'",
+	"img-src 'self' data:",
+	"object-src 'none'",
+	"script-src 'self' 'nonce-" + CSPNonce + "'",
+	"script-src-attr 'none'",
+	"style-src 'self' https: 'unsafe-inline'",
+	"upgrade-insecure-requests",
+}, "; ")
+
+type secureHeadersKey struct{}
+
+type secureHeader struct {
+	key   string
+	value string
+}
+
+func loadSecureHeaders(secureHeadersOptions SecureHeadersOptions) []secureHeader {
+	cspKey := "Content-Security-Policy"
+	if secureHeadersOptions.IsCSPReportOnly {
+		cspKey = "Content-Security-Policy-Report-Only"
+	}
+
+	secureHeaders := []secureHeader{
+		{cspKey, secureHeadersOptions.ContentSecurityPolicy},
+		{"Strict-Transport-Security", secureHeadersOptions.StrictTransportSecurity},
+		{"X-Frame-Options", secureHeadersOptions.XFrameOptions},
+		{"X-Content-Type-Options", secureHeadersOptions.XContentTypeOptions},
+		{"Referrer-Policy", secureHeadersOptions.ReferrerPolicy},
+		{"Permissions-Policy", secureHeadersOptions.PermissionsPolicy},
+		{"Cross-Origin-Opener-Policy", secureHeadersOptions.CrossOriginOpenerPolicy},
+		{"Cross-Origin-Resource-Policy", secureHeadersOptions.CrossOriginResourcePolicy},
+		{"Cross-Origin-Embedder-Policy", secureHeadersOptions.CrossOriginEmbedderPolicy},
+	}
+
+	defaultValues := map[string]string{
+		cspKey:                         defaultContentSecurityPolicy,
+		"Strict-Transport-Security":    "max-age=31536000; includeSubDomains",
+		"X-Frame-Options":              "SAMEORIGIN",
+		"X-Content-Type-Options":       "nosniff",
+		"Referrer-Policy":              "no-referrer",
+		"Permissions-Policy":           "camera=(), microphone=(), geolocation=()",
+		"Cross-Origin-Opener-Policy":   "same-origin",
+		"Cross-Origin-Resource-Policy": "same-origin",
+	}
+
+	disabledHeaders := map[string]bool{}
+	for _, disabledHeader := range secureHeadersOptions.DisabledHeaders {
+		disabledHeaders[http.CanonicalHeaderKey(disabledHeader)] = true
+	}
+
+	for i, secureHeader := range secureHeaders {
+		if disabledHeaders[secureHeader.key] {
+			secureHeaders[i].value = ""
+		} else if secureHeader.value == "" {
+			secureHeaders[i].value = defaultValues[secureHeader.key]
+		}
+	}
+
+	// the other CSP header is removed
+	// when route overrides report only mode
+	if secureHeadersOptions.IsCSPReportOnly {
+		secureHeaders = append(secureHeaders, secureHeader{"Content-Security-Policy", ""})
+	} else {
+		secureHeaders = append(secureHeaders, secureHeader{"Content-Security-Policy-Report-Only", ""})
+	}
+
+	return secureHeaders
+}
+
+// mergeSecureHeadersOptions overrides configured options
+// by non empty values,
+// IsCSPReportOnly and DisabledHeaders are added
+func mergeSecureHeadersOptions(configured, override SecureHeadersOptions) SecureHeadersOptions {
+	merged := configured
+	merged.DisabledHeaders = append(append([]string{}, configured.DisabledHeaders...), override.DisabledHeaders...)
+	merged.IsCSPReportOnly = configured.IsCSPReportOnly || override.IsCSPReportOnly
+
+	for _, field := range []struct {
+		value    *string
+		override string
+	}{
+		{&merged.ContentSecurityPolicy, override.ContentSecurityPolicy},
+		{&merged.StrictTransportSecurity, override.StrictTransportSecurity},
+		{&merged.XFrameOptions, override.XFrameOptions},
+		{&merged.XContentTypeOptions, override.XContentTypeOptions},
+		{&merged.ReferrerPolicy, override.ReferrerPolicy},
+		{&merged.PermissionsPolicy, override.PermissionsPolicy},
+		{&merged.CrossOriginOpenerPolicy, override.CrossOriginOpenerPolicy},
+		{&merged.CrossOriginResourcePolicy, override.CrossOriginResourcePolicy},
+		{&merged.CrossOriginEmbedderPolicy, override.CrossOriginEmbedderPolicy},
+	} {
+		if field.override != "" {
+			*field.value = field.override
+		}
+	}
+
+	return merged
+}
+
+// SecureHeaders sets security headers with sensible defaults,
+// apply it again per handler through module middleware to override:
+// module.Middleware.Apply(middlewares.SecureHeaders(opts), controller.READ_embed).
+// overrides are merged onto options of previously applied SecureHeaders
+func SecureHeaders(opts ...SecureHeadersOptions) func(*ctx.Context) {
+	secureHeadersOptions := SecureHeadersOptions{}
+	if len(opts) > 0 {
+		secureHeadersOptions = opts[0]
+	}
+	secureHeaders := loadSecureHeaders(secureHeadersOptions)
+
+	return func(c *ctx.Context) {
+		if c.GetType() != ctx.HTTPType {
+			c.Next()
+			return
+		}
+
+		effectiveOptions := secureHeadersOptions
+		effectiveHeaders := secureHeaders
+		if configuredOptions, ok := c.Request.Context().Value(secureHeadersKey{}).(SecureHeadersOptions); ok {
+			effectiveOptions = mergeSecureHeadersOptions(configuredOptions, secureHeadersOptions)
+			effectiveHeaders = loadSecureHeaders(effectiveOptions)
+		}
+
+		newCtx := context.WithValue(c.Request.Context(), secureHeadersKey{}, effectiveOptions)
+		c.Request = c.Request.WithContext(newCtx)
+
+		responseHeader := c.ResponseWriter.Header()
+		for _, secureHeader := range effectiveHeaders {
+			if secureHeader.value == "" {
+				responseHeader.Del(secureHeader.key)
+				continue
+			}
+
+			value := secureHeader.value
+			if strings.Contains(value, CSPNonce) {
+				value = strings.ReplaceAll(value, CSPNonce, c.CSPNonce())
+			}
+			responseHeader.Set(secureHeader.key, value)
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,64 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dangduoc08/gogo/utils"
+)
+
+func TestSecureHeadersCSPNonce(t *testing.T) {
+	c, recorder := newMiddlewareContext(httptest.NewRequest(http.MethodGet, "/", nil))
+	if isNext, _ := runMiddleware(SecureHeaders(), c); !isNext {
+		t.Errorf(utils.ErrorMessage(isNext, true, "next should be called"))
+	}
+
+	csp := recorder.Header().Get("Content-Security-Policy")
+	nonce := c.CSPNonce()
+	if nonce == "" || strings.Contains(csp, CSPNonce) || !strings.Contains(csp, "'nonce-"+nonce+"'") {
+		t.Errorf(utils.ErrorMessage(csp, "'nonce-"+nonce+"'", "nonce of current request should be set"))
+	}
+
+	if reportOnly := recorder.Header().Get("Content-Security-Policy-Report-Only"); reportOnly != "" {
+		t.Errorf(utils.ErrorMessage(reportOnly, "", "report only CSP should not be set"))
+	}
+}
+
+func TestSecureHeadersOverride(t *testing.T) {
+	globalSecureHeaders := SecureHeaders(SecureHeadersOptions{
+		XFrameOptions:   "DENY",
+		DisabledHeaders: []string{"Strict-Transport-Security"},
+	})
+	routeSecureHeaders := SecureHeaders(SecureHeadersOptions{
+		ReferrerPolicy: "same-origin",
+	})
+
+	c, recorder := newMiddlewareContext(httptest.NewRequest(http.MethodGet, "/embed", nil))
+	isNext := false
+	c.Next = func() {
+		c.Next = func() {
+			isNext = true
+		}
+		routeSecureHeaders(c)
+	}
+	globalSecureHeaders(c)
+
+	if !isNext {
+		t.Errorf(utils.ErrorMessage(isNext, true, "next should be called"))
+	}
+
+	cases := map[string]string{
+		"X-Frame-Options":           "DENY",
+		"Referrer-Policy":           "same-origin",
+		"Strict-Transport-Security": "",
+		"X-Content-Type-Options":    "nosniff",
+	}
+
+	for k, expected := range cases {
+		if actual := recorder.Header().Get(k); actual != expected {
+			t.Errorf(utils.ErrorMessage(actual, expected, k+" should be merged onto configured options"))
+		}
+	}
+}
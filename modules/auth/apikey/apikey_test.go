@@ -0,0 +1,84 @@
+package apikey
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dangduoc08/gogo/common"
+	"github.com/dangduoc08/gogo/core"
+	"github.com/dangduoc08/gogo/utils"
+)
+
+func TestStaticKeys(t *testing.T) {
+	staticKeys := ParseKeys("billing:key_1, reports:key_2,invalid")
+
+	if len(staticKeys) != 2 {
+		t.Errorf(utils.ErrorMessage(len(staticKeys), 2, "keys should be parsed"))
+	}
+
+	if principal, ok := staticKeys.Validate(nil, "key_2"); !ok || principal.ID != "reports" {
+		t.Errorf(utils.ErrorMessage(principal.ID, "reports", "principal should be resolved"))
+	}
+
+	if _, ok := staticKeys.Validate(nil, "key_3"); ok {
+		t.Errorf(utils.ErrorMessage(ok, false, "unknown key should be rejected"))
+	}
+}
+
+type reportController struct {
+	common.REST
+	common.Guard
+}
+
+func (instance reportController) NewController() core.Controller {
+	instance.BindGuard(APIKeyGuard{})
+
+	return instance
+}
+
+func (instance reportController) READ_reports(principal Principal) string {
+	return principal.ID
+}
+
+func TestAPIKeyGuard(t *testing.T) {
+	app := core.New()
+	app.Create(
+		core.ModuleBuilder().
+			Imports(Register(&APIKeyModuleOptions{
+				Keys: StaticKeys{"billing": "key_1"},
+			})).
+			Controllers(reportController{}).
+			Build(),
+	)
+
+	cases := map[string]struct {
+		key      string
+		expected int
+	}{
+		"missing key": {"", http.StatusUnauthorized},
+		"invalid key": {"key_2", http.StatusUnauthorized},
+		"valid key":   {"key_1", http.StatusOK},
+	}
+
+	for desc, testCase := range cases {
+		r := httptest.NewRequest(http.MethodGet, "/reports", nil)
+		if testCase.key != "" {
+			r.Header.Set("X-API-Key", testCase.key)
+		}
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, r)
+
+		if w.Code != testCase.expected {
+			t.Errorf(utils.ErrorMessage(w.Code, testCase.expected, desc+" status should be equal"))
+		}
+
+		if testCase.expected == http.StatusUnauthorized {
+			if challenge := w.Header().Get("WWW-Authenticate"); challenge != `APIKey header="X-API-Key"` {
+				t.Errorf(utils.ErrorMessage(challenge, `APIKey header="X-API-Key"`, desc+" should be challenged"))
+			}
+		} else if w.Body.String() != "billing" {
+			t.Errorf(utils.ErrorMessage(w.Body.String(), "billing", desc+" principal should be injected"))
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package apikey
+
+import (
+	"context"
+
+	"github.com/dangduoc08/gogo/ctx"
+	"github.com/dangduoc08/gogo/exception"
+)
+
+// APIKeyGuard validates API key of header,
+// or of query if it was enabled.
+// resolved client is injected into handlers by Principal
+type APIKeyGuard struct {
+	APIKeyService APIKeyService
+}
+
+func (instance APIKeyGuard) CanActivate(c *ctx.Context) bool {
+	key := c.Request.Header.Get(instance.APIKeyService.Header)
+	if key == "" && instance.APIKeyService.Query != "" {
+		key = c.Request.URL.Query().Get(instance.APIKeyService.Query)
+	}
+
+	if key == "" {
+		instance.reject(c, "Missing API key")
+	}
+
+	principal, ok := instance.APIKeyService.Validator.Validate(c, key)
+	if !ok {
+		instance.reject(c, "Invalid API key")
+	}
+
+	newCtx := context.WithValue(c.Request.Context(), principalKey{}, principal)
+	c.Request = c.Request.WithContext(newCtx)
+
+	return true
+}
+
+func (instance APIKeyGuard) reject(c *ctx.Context, message string) {
+	if c.GetType() == ctx.HTTPType {
+		c.ResponseWriter.Header().Set("WWW-Authenticate", `APIKey header="`+instance.APIKeyService.Header+`"`)
+	}
+
+	panic(exception.UnauthorizedException(message))
+}
@@ -0,0 +1,78 @@
+package apikey
+
+import (
+	"errors"
+
+	"github.com/dangduoc08/gogo/core"
+	"github.com/dangduoc08/gogo/modules/config"
+)
+
+type (
+	APIKeyConfigLoadFn = func(config.ConfigService) *APIKeyModuleOptions
+)
+
+type APIKeyModuleOptions struct {
+	IsGlobal bool
+
+	// X-API-Key header by default,
+	// key is read from query only if Query was set
+	Header string
+	Query  string
+
+	// keys of client IDs, e.g. ParseKeys of env.
+	// Validator takes precedence,
+	// e.g. to look keys up in database
+	Keys      StaticKeys
+	Validator Validator
+}
+
+func loadAPIKeyOptions(opts *APIKeyModuleOptions) *APIKeyModuleOptions {
+	if opts == nil {
+		opts = &APIKeyModuleOptions{}
+	}
+
+	apiKeyOptions := &APIKeyModuleOptions{
+		IsGlobal:  opts.IsGlobal,
+		Header:    opts.Header,
+		Query:     opts.Query,
+		Keys:      opts.Keys,
+		Validator: opts.Validator,
+	}
+
+	if apiKeyOptions.Header == "" {
+		apiKeyOptions.Header = "X-API-Key"
+	}
+
+	if apiKeyOptions.Validator == nil {
+		if len(apiKeyOptions.Keys) == 0 {
+			panic(errors.New("api keys or validator are required"))
+		}
+		apiKeyOptions.Validator = apiKeyOptions.Keys
+	}
+
+	return apiKeyOptions
+}
+
+func Register(opts *APIKeyModuleOptions) *core.Module {
+	apiKeyOptions := loadAPIKeyOptions(opts)
+	apiKeyService := APIKeyService{
+		Header:    apiKeyOptions.Header,
+		Query:     apiKeyOptions.Query,
+		Validator: apiKeyOptions.Validator,
+	}
+
+	module := core.ModuleBuilder().
+		Providers(apiKeyService).
+		Build()
+
+	module.IsGlobal = apiKeyOptions.IsGlobal
+	return module
+}
+
+// RegisterWithConfig reads header and keys
+// from globally registered ConfigService
+func RegisterWithConfig(load APIKeyConfigLoadFn) func(config.ConfigService) *core.Module {
+	return func(configService config.ConfigService) *core.Module {
+		return Register(load(configService))
+	}
+}
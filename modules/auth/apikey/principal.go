@@ -0,0 +1,34 @@
+package apikey
+
+import (
+	"errors"
+
+	"github.com/dangduoc08/gogo/common"
+	"github.com/dangduoc08/gogo/ctx"
+)
+
+type principalKey struct{}
+
+// Principal is client which owns API key of request,
+// ID is client ID of StaticKeys,
+// custom Validator may attach e.g. scopes of key to Attributes
+type Principal struct {
+	ID         string
+	Attributes map[string]any
+}
+
+func (principal Principal) Transform(c *ctx.Context, metadata common.ArgumentMetadata) any {
+	resolvedPrincipal, ok := GetPrincipal(c)
+	if !ok {
+		panic(errors.New("api key guard was not applied"))
+	}
+
+	return resolvedPrincipal
+}
+
+// GetPrincipal returns client authenticated by APIKeyGuard,
+// ok is false if route isn't guarded by API key
+func GetPrincipal(c *ctx.Context) (Principal, bool) {
+	principal, ok := c.Request.Context().Value(principalKey{}).(Principal)
+	return principal, ok
+}
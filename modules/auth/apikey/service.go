@@ -0,0 +1,17 @@
+package apikey
+
+import (
+	"github.com/dangduoc08/gogo/core"
+)
+
+// APIKeyService holds where APIKeyGuard reads key from
+// and how it's validated
+type APIKeyService struct {
+	Header    string
+	Query     string
+	Validator Validator
+}
+
+func (apiKeyService APIKeyService) NewProvider() core.Provider {
+	return apiKeyService
+}
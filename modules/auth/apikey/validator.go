@@ -0,0 +1,61 @@
+package apikey
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"strings"
+
+	"github.com/dangduoc08/gogo/ctx"
+)
+
+type Validator interface {
+	Validate(c *ctx.Context, key string) (Principal, bool)
+}
+
+// ValidateFunc adapts callback to Validator
+type ValidateFunc func(c *ctx.Context, key string) (Principal, bool)
+
+func (validateFunc ValidateFunc) Validate(c *ctx.Context, key string) (Principal, bool) {
+	return validateFunc(c, key)
+}
+
+// StaticKeys validates keys of clients,
+// keys is map of client ID to key
+type StaticKeys map[string]string
+
+// Validate compares key with all keys in constant time
+// to not leak which key was matched
+func (staticKeys StaticKeys) Validate(c *ctx.Context, key string) (Principal, bool) {
+	keyHash := sha256.Sum256([]byte(key))
+	principalID := ""
+	isMatched := 0
+
+	for id, staticKey := range staticKeys {
+		staticKeyHash := sha256.Sum256([]byte(staticKey))
+		if subtle.ConstantTimeCompare(keyHash[:], staticKeyHash[:]) == 1 {
+			principalID = id
+			isMatched = 1
+		}
+	}
+
+	if isMatched == 0 {
+		return Principal{}, false
+	}
+
+	return Principal{ID: principalID}, true
+}
+
+// ParseKeys parses keys of
+// "id:key,id:key" format,
+// e.g. from ConfigService
+func ParseKeys(s string) StaticKeys {
+	staticKeys := StaticKeys{}
+	for _, pair := range strings.Split(s, ",") {
+		id, key, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if ok && id != "" && key != "" {
+			staticKeys[id] = key
+		}
+	}
+
+	return staticKeys
+}
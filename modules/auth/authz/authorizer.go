@@ -0,0 +1,98 @@
+package authz
+
+import (
+	"fmt"
+
+	"github.com/dangduoc08/gogo/core"
+	"github.com/dangduoc08/gogo/ctx"
+	"github.com/dangduoc08/gogo/exception"
+)
+
+// Authorizer checks permissions of subjects
+// by roles (RBAC) and policies (ABAC)
+type Authorizer struct {
+	Roles       map[string]Role
+	Policies    []Policy
+	SubjectFunc SubjectFunc
+}
+
+func (authorizer Authorizer) NewProvider() core.Provider {
+	return authorizer
+}
+
+// Subject returns identity of current request
+func (authorizer Authorizer) Subject(c *ctx.Context) (Subject, bool) {
+	return authorizer.SubjectFunc(c)
+}
+
+// Can reports whether subject can do action on resource,
+// resource is either name of resource or resource value.
+// deny policies take precedence,
+// then role permissions and allow policies are checked.
+// policies of unknown effects are ignored
+func (authorizer Authorizer) Can(subject Subject, action string, resource any) bool {
+	roles := authorizer.InheritedRoles(subject.Roles...)
+	resourceName := getResourceName(resource)
+
+	for _, policy := range authorizer.Policies {
+		if normalizeEffect(policy.Effect) == Deny && policy.isMatched(subject, roles, action, resourceName, resource) {
+			return false
+		}
+	}
+
+	for _, role := range roles {
+		for _, permission := range authorizer.Roles[role].Permissions {
+			if isPermissionMatched(permission, action, resourceName) {
+				return true
+			}
+		}
+	}
+
+	for _, policy := range authorizer.Policies {
+		if normalizeEffect(policy.Effect) == Allow && policy.isMatched(subject, roles, action, resourceName, resource) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Enforce panics ForbiddenException
+// if subject can't do action on resource
+func (authorizer Authorizer) Enforce(subject Subject, action string, resource any) {
+	if !authorizer.Can(subject, action, resource) {
+		panic(exception.ForbiddenException(fmt.Sprintf("Can't %v %v", action, getResourceName(resource))))
+	}
+}
+
+// HasRole reports whether subject has any of roles,
+// directly or by inheritance
+func (authorizer Authorizer) HasRole(subject Subject, roles ...string) bool {
+	return hasAny(authorizer.InheritedRoles(subject.Roles...), roles)
+}
+
+// InheritedRoles returns roles
+// and all roles inherited by them
+func (authorizer Authorizer) InheritedRoles(roles ...string) []string {
+	inheritedRoles := []string{}
+	isVisited := map[string]bool{}
+
+	var visit func(role string)
+	visit = func(role string) {
+		if isVisited[role] {
+			return
+		}
+		isVisited[role] = true
+		inheritedRoles = append(inheritedRoles, role)
+
+		for _, inheritedRole := range authorizer.Roles[role].Inherits {
+			visit(inheritedRole)
+		}
+	}
+
+	for _, role := range roles {
+		visit(role)
+	}
+
+	return inheritedRoles
+}
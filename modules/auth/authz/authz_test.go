@@ -0,0 +1,189 @@
+package authz
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dangduoc08/gogo/utils"
+)
+
+type article struct {
+	AuthorID string `json:"authorId"`
+	IsLocked bool
+}
+
+type post struct {
+	Team string `json:"team"`
+}
+
+func (p post) ResourceName() string {
+	return "posts"
+}
+
+func newTestAuthorizer() Authorizer {
+	return Authorizer{
+		Roles: loadRoles([]Role{
+			{Name: "viewer", Permissions: []string{"read:article"}},
+			{Name: "editor", Permissions: []string{"create:article"}, Inherits: []string{"viewer"}},
+			{Name: "admin", Permissions: []string{"*"}, Inherits: []string{"editor"}},
+		}),
+		Policies: []Policy{
+			{
+				Action:   "update",
+				Resource: "article",
+				Effect:   Allow,
+				Roles:    []string{"editor"},
+				Conditions: []Condition{
+					{Left: "resource.authorId", Operator: OperatorEq, Right: "subject.id"},
+				},
+			},
+			{
+				Action:   "*",
+				Resource: "article",
+				Effect:   Deny,
+				Condition: func(subject Subject, resource any) bool {
+					a, ok := resource.(article)
+					return ok && a.IsLocked
+				},
+			},
+		},
+	}
+}
+
+func TestAuthorizerRBAC(t *testing.T) {
+	authorizer := newTestAuthorizer()
+	editor := Subject{ID: "1", Roles: []string{"editor"}}
+
+	if !authorizer.Can(editor, "read", "article") {
+		t.Errorf(utils.ErrorMessage(false, true, "inherited permission should be granted"))
+	}
+
+	if authorizer.Can(editor, "delete", "article") {
+		t.Errorf(utils.ErrorMessage(true, false, "permission should not be granted"))
+	}
+
+	if !authorizer.HasRole(Subject{Roles: []string{"admin"}}, "viewer") {
+		t.Errorf(utils.ErrorMessage(false, true, "inherited role should be granted"))
+	}
+
+	if roles := authorizer.InheritedRoles("admin"); len(roles) != 3 {
+		t.Errorf(utils.ErrorMessage(roles, []string{"admin", "editor", "viewer"}, "inherited roles should be equal"))
+	}
+}
+
+func TestAuthorizerABAC(t *testing.T) {
+	authorizer := newTestAuthorizer()
+	editor := Subject{ID: "1", Roles: []string{"editor"}}
+
+	if !authorizer.Can(editor, "update", article{AuthorID: "1"}) {
+		t.Errorf(utils.ErrorMessage(false, true, "author should update own article"))
+	}
+
+	if authorizer.Can(editor, "update", article{AuthorID: "2"}) {
+		t.Errorf(utils.ErrorMessage(true, false, "author should not update others article"))
+	}
+
+	if authorizer.Can(Subject{Roles: []string{"admin"}}, "read", article{IsLocked: true}) {
+		t.Errorf(utils.ErrorMessage(true, false, "deny policy should take precedence"))
+	}
+
+	if authorizer.Can(Subject{ID: "1"}, "update", article{AuthorID: "1"}) {
+		t.Errorf(utils.ErrorMessage(true, false, "subject without role should not match role policy"))
+	}
+}
+
+func TestLoadPolicyFile(t *testing.T) {
+	policyFile := filepath.Join(t.TempDir(), "policy.json")
+	os.WriteFile(policyFile, []byte(`{
+		"roles": [{"name": "member", "permissions": ["read:posts"]}],
+		"policies": [{
+			"action": "update",
+			"resource": "posts",
+			"effect": "Allow",
+			"conditions": [{"left": "subject.teams", "operator": "contains", "right": "resource.team"}]
+		}]
+	}`), 0644)
+
+	policyDocument, err := LoadPolicyFile(policyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authorizer := Authorizer{
+		Roles:    loadRoles(policyDocument.Roles),
+		Policies: policyDocument.Policies,
+	}
+	member := Subject{
+		Roles:      []string{"member"},
+		Attributes: map[string]any{"teams": []any{"gogo"}},
+	}
+
+	if !authorizer.Can(member, "read", "posts") {
+		t.Errorf(utils.ErrorMessage(false, true, "loaded role permission should be granted"))
+	}
+
+	if !authorizer.Can(member, "update", &post{Team: "gogo"}) {
+		t.Errorf(utils.ErrorMessage(false, true, "loaded policy should be matched"))
+	}
+
+	if _, err := LoadPolicyFile(filepath.Join(t.TempDir(), "policy.yaml")); err == nil {
+		t.Errorf(utils.ErrorMessage(err, "error", "missing YAML file should return error"))
+	}
+}
+
+func TestAuthorizerFailClosed(t *testing.T) {
+	subject := Subject{ID: "1", Roles: []string{"member"}}
+	authorizer := Authorizer{
+		Roles: loadRoles([]Role{{Name: "member", Permissions: []string{":post", "read:"}}}),
+		Policies: []Policy{
+			{Action: "update", Resource: "post", Effect: "allw"},
+			{Effect: Allow},
+			{Action: "delete", Resource: "post", Effect: Allow},
+			{Action: "delete", Resource: "post", Effect: "Deny"},
+		},
+	}
+
+	cases := map[string]string{
+		"update": "post",
+		"create": "post",
+		"delete": "post",
+		"read":   "comment",
+	}
+
+	for action, resource := range cases {
+		if authorizer.Can(subject, action, resource) {
+			t.Errorf(utils.ErrorMessage(true, false, action+" "+resource+" should not be granted"))
+		}
+	}
+}
+
+func TestLoadPolicyFileValidation(t *testing.T) {
+	invalidPolicies := []string{
+		`{"policies": [{"action": "delete", "resource": "posts", "effect": "allw"}]}`,
+		`{"policies": [{"action": "delete", "resource": "posts"}]}`,
+		`{"policies": [{"actoin": "delete", "resource": "posts", "effect": "allow"}]}`,
+		`{"policies": [{"action": "delete", "resuorce": "posts", "effect": "deny"}]}`,
+	}
+
+	for _, invalidPolicy := range invalidPolicies {
+		policyFile := filepath.Join(t.TempDir(), "policy.json")
+		os.WriteFile(policyFile, []byte(invalidPolicy), 0644)
+
+		if _, err := LoadPolicyFile(policyFile); err == nil {
+			t.Errorf(utils.ErrorMessage(err, "error", "invalid policy should return error"))
+		}
+	}
+
+	policyFile := filepath.Join(t.TempDir(), "policy.json")
+	os.WriteFile(policyFile, []byte(`{"policies": [{"action": "*", "resource": "posts", "effect": " DENY "}]}`), 0644)
+
+	policyDocument, err := LoadPolicyFile(policyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if effect := policyDocument.Policies[0].Effect; effect != Deny {
+		t.Errorf(utils.ErrorMessage(effect, Deny, "effect should be normalized"))
+	}
+}
@@ -0,0 +1,61 @@
+package authz
+
+import (
+	"strings"
+
+	"github.com/dangduoc08/gogo/core"
+	"github.com/dangduoc08/gogo/ctx"
+	"github.com/dangduoc08/gogo/exception"
+)
+
+// metadata keys read by AuthzGuard,
+// e.g. instance.SetMetadata(authz.RolesKey, []string{"admin"}, instance.DELETE_users_BY_id)
+const (
+	RolesKey       = "authz:roles"
+	PermissionsKey = "authz:permissions"
+)
+
+// AuthzGuard requires subject to have any of roles
+// and all of "action:resource" permissions.
+// requirements are read from Roles and Permissions fields
+// and from handler or controller metadata
+type AuthzGuard struct {
+	Authorizer  Authorizer
+	Reflector   core.Reflector
+	Roles       []string
+	Permissions []string
+}
+
+func (instance AuthzGuard) CanActivate(c *ctx.Context) bool {
+	roles := append([]string{}, instance.Roles...)
+	if metadataRoles, ok := instance.Reflector.Get(c, RolesKey).([]string); ok {
+		roles = append(roles, metadataRoles...)
+	}
+
+	permissions := append([]string{}, instance.Permissions...)
+	if metadataPermissions, ok := instance.Reflector.Get(c, PermissionsKey).([]string); ok {
+		permissions = append(permissions, metadataPermissions...)
+	}
+
+	if len(roles) == 0 && len(permissions) == 0 {
+		return true
+	}
+
+	subject, ok := instance.Authorizer.Subject(c)
+	if !ok {
+		panic(exception.UnauthorizedException("Unauthorized"))
+	}
+
+	if len(roles) > 0 && !instance.Authorizer.HasRole(subject, roles...) {
+		return false
+	}
+
+	for _, permission := range permissions {
+		action, resource, _ := strings.Cut(permission, ":")
+		if !instance.Authorizer.Can(subject, action, resource) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,96 @@
+package authz
+
+import (
+	"fmt"
+
+	"github.com/dangduoc08/gogo/core"
+)
+
+type AuthzModuleOptions struct {
+	IsGlobal bool
+	Roles    []Role
+	Policies []Policy
+
+	// roles and policies are merged
+	// with ones defined in Go
+	PolicyFile string
+
+	// required to load YAML policy file
+	Unmarshal func([]byte, any) error
+
+	// subject is read from jwt.JWTGuard by default
+	SubjectFunc SubjectFunc
+}
+
+func loadAuthzOptions(opts *AuthzModuleOptions) *AuthzModuleOptions {
+	if opts == nil {
+		opts = &AuthzModuleOptions{}
+	}
+
+	authzOptions := &AuthzModuleOptions{
+		IsGlobal:    opts.IsGlobal,
+		Roles:       opts.Roles,
+		Policies:    opts.Policies,
+		PolicyFile:  opts.PolicyFile,
+		Unmarshal:   opts.Unmarshal,
+		SubjectFunc: opts.SubjectFunc,
+	}
+
+	if authzOptions.PolicyFile != "" {
+		policyDocument, err := LoadPolicyFile(authzOptions.PolicyFile, authzOptions.Unmarshal)
+		if err != nil {
+			panic(err)
+		}
+
+		authzOptions.Roles = append(append([]Role{}, authzOptions.Roles...), policyDocument.Roles...)
+		authzOptions.Policies = append(append([]Policy{}, authzOptions.Policies...), policyDocument.Policies...)
+	}
+
+	policies, err := normalizePolicies(authzOptions.Policies)
+	if err != nil {
+		panic(err)
+	}
+	authzOptions.Policies = policies
+
+	if authzOptions.SubjectFunc == nil {
+		authzOptions.SubjectFunc = FromJWT
+	}
+
+	return authzOptions
+}
+
+func loadRoles(roles []Role) map[string]Role {
+	roleMap := map[string]Role{}
+	for _, role := range roles {
+		if _, ok := roleMap[role.Name]; ok {
+			panic(fmt.Errorf("authz: role %v is duplicated", role.Name))
+		}
+		roleMap[role.Name] = role
+	}
+
+	for _, role := range roles {
+		for _, inheritedRole := range role.Inherits {
+			if _, ok := roleMap[inheritedRole]; !ok {
+				panic(fmt.Errorf("authz: role %v inherits undefined role %v", role.Name, inheritedRole))
+			}
+		}
+	}
+
+	return roleMap
+}
+
+func Register(opts *AuthzModuleOptions) *core.Module {
+	authzOptions := loadAuthzOptions(opts)
+	authorizer := Authorizer{
+		Roles:       loadRoles(authzOptions.Roles),
+		Policies:    authzOptions.Policies,
+		SubjectFunc: authzOptions.SubjectFunc,
+	}
+
+	module := core.ModuleBuilder().
+		Providers(authorizer).
+		Build()
+
+	module.IsGlobal = authzOptions.IsGlobal
+	return module
+}
@@ -0,0 +1,311 @@
+package authz
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+const (
+	Allow = "allow"
+	Deny  = "deny"
+)
+
+const (
+	OperatorEq       = "eq"
+	OperatorNe       = "ne"
+	OperatorIn       = "in"
+	OperatorContains = "contains"
+)
+
+// Role grants permissions
+// of "action:resource" format,
+// "*" matches any action or resource.
+// permissions of inherited roles are granted too
+type Role struct {
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+	Inherits    []string `json:"inherits"`
+}
+
+// Policy allows or denies action on resource
+// when subject has any of Roles (any subject if empty)
+// and all conditions are satisfied
+type Policy struct {
+	Action     string      `json:"action"`
+	Resource   string      `json:"resource"`
+	Effect     string      `json:"effect"`
+	Roles      []string    `json:"roles"`
+	Conditions []Condition `json:"conditions"`
+
+	// Go defined condition,
+	// resource is nil when checked by guard
+	Condition func(subject Subject, resource any) bool `json:"-"`
+}
+
+// Condition compares attributes,
+// operands prefixed by "subject." or "resource."
+// are resolved from subject or resource,
+// the others are literal values
+type Condition struct {
+	Left     any    `json:"left"`
+	Operator string `json:"operator"`
+	Right    any    `json:"right"`
+}
+
+type PolicyDocument struct {
+	Roles    []Role   `json:"roles"`
+	Policies []Policy `json:"policies"`
+}
+
+// Resource names kind of resource values,
+// type name is used otherwise
+type Resource interface {
+	ResourceName() string
+}
+
+// LoadPolicyFile loads JSON policy document,
+// YAML files require unmarshal function of any YAML library
+func LoadPolicyFile(path string, unmarshal ...func([]byte, any) error) (PolicyDocument, error) {
+	policyDocument := PolicyDocument{}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return policyDocument, err
+	}
+
+	unmarshalFn := json.Unmarshal
+	if len(unmarshal) > 0 && unmarshal[0] != nil {
+		unmarshalFn = unmarshal[0]
+	} else if ext := strings.ToLower(filepath.Ext(path)); ext == ".yaml" || ext == ".yml" {
+		return policyDocument, errors.New("authz: unmarshal function is required to load YAML policy file")
+	}
+
+	if err := unmarshalFn(data, &policyDocument); err != nil {
+		return policyDocument, err
+	}
+
+	policyDocument.Policies, err = normalizePolicies(policyDocument.Policies)
+	if err != nil {
+		return policyDocument, err
+	}
+
+	return policyDocument, nil
+}
+
+// normalizePolicies lower cases effects
+// and rejects policies which would match unintentionally
+func normalizePolicies(policies []Policy) ([]Policy, error) {
+	normalizedPolicies := make([]Policy, len(policies))
+
+	for i, policy := range policies {
+		policy.Effect = normalizeEffect(policy.Effect)
+		if policy.Effect != Allow && policy.Effect != Deny {
+			return nil, fmt.Errorf("authz: policy %v has invalid effect, must be %v or %v", i, Allow, Deny)
+		}
+
+		if policy.Action == "" || policy.Resource == "" {
+			return nil, fmt.Errorf("authz: policy %v requires action and resource, use \"*\" to match any", i)
+		}
+
+		normalizedPolicies[i] = policy
+	}
+
+	return normalizedPolicies, nil
+}
+
+func normalizeEffect(effect string) string {
+	return strings.ToLower(strings.TrimSpace(effect))
+}
+
+func (policy Policy) isMatched(subject Subject, roles []string, action, resourceName string, resource any) bool {
+	if !isPatternMatched(policy.Action, action) || !isPatternMatched(policy.Resource, resourceName) {
+		return false
+	}
+
+	if len(policy.Roles) > 0 && !hasAny(roles, policy.Roles) {
+		return false
+	}
+
+	for _, condition := range policy.Conditions {
+		if !condition.isSatisfied(subject, resource) {
+			return false
+		}
+	}
+
+	return policy.Condition == nil || policy.Condition(subject, resource)
+}
+
+func (condition Condition) isSatisfied(subject Subject, resource any) bool {
+	left, ok := resolveOperand(condition.Left, subject, resource)
+	if !ok {
+		return false
+	}
+
+	right, ok := resolveOperand(condition.Right, subject, resource)
+	if !ok {
+		return false
+	}
+
+	switch condition.Operator {
+	case OperatorEq, "":
+		return isEqual(left, right)
+	case OperatorNe:
+		return !isEqual(left, right)
+	case OperatorIn:
+		return isContained(right, left)
+	case OperatorContains:
+		return isContained(left, right)
+	}
+
+	return false
+}
+
+func resolveOperand(operand any, subject Subject, resource any) (any, bool) {
+	path, ok := operand.(string)
+	if !ok {
+		return operand, true
+	}
+
+	if k, ok := strings.CutPrefix(path, "subject."); ok {
+		switch k {
+		case "id":
+			return subject.ID, true
+		case "roles":
+			return subject.Roles, true
+		}
+		return resolvePath(subject.Attributes, k)
+	}
+
+	if k, ok := strings.CutPrefix(path, "resource."); ok {
+		return resolvePath(resource, k)
+	}
+
+	return operand, true
+}
+
+// resolvePath reads nested value of maps
+// or struct fields by name or json tag
+func resolvePath(v any, path string) (any, bool) {
+	value := reflect.ValueOf(v)
+
+	for _, k := range strings.Split(path, ".") {
+		for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+			if value.IsNil() {
+				return nil, false
+			}
+			value = value.Elem()
+		}
+
+		switch value.Kind() {
+		case reflect.Map:
+			value = value.MapIndex(reflect.ValueOf(k))
+			if !value.IsValid() {
+				return nil, false
+			}
+
+		case reflect.Struct:
+			field, ok := findField(value.Type(), k)
+			if !ok {
+				return nil, false
+			}
+			value = value.FieldByIndex(field.Index)
+
+		default:
+			return nil, false
+		}
+	}
+
+	if !value.IsValid() || !value.CanInterface() {
+		return nil, false
+	}
+
+	return value.Interface(), true
+}
+
+func findField(structType reflect.Type, k string) (reflect.StructField, bool) {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if jsonName == k || strings.EqualFold(field.Name, k) {
+			return field, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+func isEqual(left, right any) bool {
+	return fmt.Sprint(left) == fmt.Sprint(right)
+}
+
+func isContained(collection, v any) bool {
+	value := reflect.ValueOf(collection)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return false
+	}
+
+	for i := 0; i < value.Len(); i++ {
+		if isEqual(value.Index(i).Interface(), v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func getResourceName(resource any) string {
+	switch r := resource.(type) {
+	case string:
+		return r
+	case Resource:
+		return r.ResourceName()
+	}
+
+	resourceType := reflect.TypeOf(resource)
+	for resourceType != nil && resourceType.Kind() == reflect.Pointer {
+		resourceType = resourceType.Elem()
+	}
+
+	if resourceType == nil {
+		return ""
+	}
+
+	return resourceType.Name()
+}
+
+// isPatternMatched matches action or resource,
+// "*" matches anything, empty pattern matches nothing
+func isPatternMatched(pattern, v string) bool {
+	return pattern != "" && (pattern == "*" || pattern == v)
+}
+
+func isPermissionMatched(permission, action, resourceName string) bool {
+	if permission == "*" {
+		return true
+	}
+
+	permittedAction, permittedResource, _ := strings.Cut(permission, ":")
+	return isPatternMatched(permittedAction, action) &&
+		isPatternMatched(permittedResource, resourceName)
+}
+
+func hasAny(values, expected []string) bool {
+	for _, v := range values {
+		for _, e := range expected {
+			if v == e {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,50 @@
+package authz
+
+import (
+	"strings"
+
+	"github.com/dangduoc08/gogo/ctx"
+	"github.com/dangduoc08/gogo/modules/auth/jwt"
+)
+
+// Subject is identity
+// which permissions are checked for
+type Subject struct {
+	ID         string
+	Roles      []string
+	Attributes map[string]any
+}
+
+// SubjectFunc returns identity of current request,
+// false if request was not authenticated
+type SubjectFunc = func(*ctx.Context) (Subject, bool)
+
+// FromJWT reads subject from claims
+// verified by jwt.JWTGuard,
+// roles are read from "roles" claim
+// as array or space-delimited string
+func FromJWT(c *ctx.Context) (Subject, bool) {
+	payload, ok := jwt.GetPayload(c)
+	if !ok {
+		return Subject{}, false
+	}
+
+	subject := Subject{
+		ID:         payload.Subject(),
+		Roles:      []string{},
+		Attributes: payload.Claims,
+	}
+
+	switch roles := payload.Claims["roles"].(type) {
+	case string:
+		subject.Roles = strings.Fields(roles)
+	case []any:
+		for _, role := range roles {
+			if s, ok := role.(string); ok {
+				subject.Roles = append(subject.Roles, s)
+			}
+		}
+	}
+
+	return subject, true
+}
@@ -0,0 +1,102 @@
+package basic
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dangduoc08/gogo/common"
+	"github.com/dangduoc08/gogo/core"
+	"github.com/dangduoc08/gogo/utils"
+)
+
+func TestStaticUsers(t *testing.T) {
+	staticUsers := ParseUsers("admin:s3cret:with:colons,guest:")
+
+	if password := staticUsers["admin"]; password != "s3cret:with:colons" {
+		t.Errorf(utils.ErrorMessage(password, "s3cret:with:colons", "password should be parsed"))
+	}
+
+	if principal, ok := staticUsers.Validate(nil, "admin", "s3cret:with:colons"); !ok || principal.ID != "admin" {
+		t.Errorf(utils.ErrorMessage(principal.ID, "admin", "principal should be resolved"))
+	}
+
+	if _, ok := staticUsers.Validate(nil, "admin", "s3cret"); ok {
+		t.Errorf(utils.ErrorMessage(ok, false, "wrong password should be rejected"))
+	}
+
+	if _, ok := staticUsers.Validate(nil, "admins3cret:with:colons", ""); ok {
+		t.Errorf(utils.ErrorMessage(ok, false, "credentials should not be concatenated"))
+	}
+
+	if _, ok := staticUsers["guest"]; ok {
+		t.Errorf(utils.ErrorMessage(ok, false, "user without password should be skipped"))
+	}
+
+	if _, ok := staticUsers.Validate(nil, "guest", ""); ok {
+		t.Errorf(utils.ErrorMessage(ok, false, "empty password should be rejected"))
+	}
+
+	if _, ok := (StaticUsers{"guest": ""}).Validate(nil, "guest", ""); ok {
+		t.Errorf(utils.ErrorMessage(ok, false, "static user without password should be rejected"))
+	}
+}
+
+type accountController struct {
+	common.REST
+	common.Guard
+}
+
+func (instance accountController) NewController() core.Controller {
+	instance.BindGuard(BasicAuthGuard{})
+
+	return instance
+}
+
+func (instance accountController) READ_accounts(principal Principal) string {
+	return principal.ID
+}
+
+func TestBasicAuthGuard(t *testing.T) {
+	app := core.New()
+	app.Create(
+		core.ModuleBuilder().
+			Imports(Register(&BasicAuthModuleOptions{
+				Realm: "Accounts",
+				Users: StaticUsers{"admin": "s3cret"},
+			})).
+			Controllers(accountController{}).
+			Build(),
+	)
+
+	cases := map[string]struct {
+		username string
+		password string
+		expected int
+	}{
+		"missing credentials": {"", "", http.StatusUnauthorized},
+		"invalid credentials": {"admin", "guess", http.StatusUnauthorized},
+		"valid credentials":   {"admin", "s3cret", http.StatusOK},
+	}
+
+	for desc, testCase := range cases {
+		r := httptest.NewRequest(http.MethodGet, "/accounts", nil)
+		if testCase.username != "" {
+			r.SetBasicAuth(testCase.username, testCase.password)
+		}
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, r)
+
+		if w.Code != testCase.expected {
+			t.Errorf(utils.ErrorMessage(w.Code, testCase.expected, desc+" status should be equal"))
+		}
+
+		if testCase.expected == http.StatusUnauthorized {
+			if challenge := w.Header().Get("WWW-Authenticate"); challenge != `Basic realm="Accounts", charset="UTF-8"` {
+				t.Errorf(utils.ErrorMessage(challenge, `Basic realm="Accounts", charset="UTF-8"`, desc+" should be challenged"))
+			}
+		} else if w.Body.String() != "admin" {
+			t.Errorf(utils.ErrorMessage(w.Body.String(), "admin", desc+" principal should be injected"))
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package basic
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/dangduoc08/gogo/ctx"
+	"github.com/dangduoc08/gogo/exception"
+)
+
+// BasicAuthGuard validates credentials of Authorization header,
+// resolved user is injected into handlers by Principal
+type BasicAuthGuard struct {
+	BasicAuthService BasicAuthService
+}
+
+func (instance BasicAuthGuard) CanActivate(c *ctx.Context) bool {
+	username, password, ok := c.Request.BasicAuth()
+	if !ok {
+		instance.reject(c, "Missing credentials")
+	}
+
+	principal, ok := instance.BasicAuthService.Validator.Validate(c, username, password)
+	if !ok {
+		instance.reject(c, "Invalid credentials")
+	}
+
+	newCtx := context.WithValue(c.Request.Context(), principalKey{}, principal)
+	c.Request = c.Request.WithContext(newCtx)
+
+	return true
+}
+
+func (instance BasicAuthGuard) reject(c *ctx.Context, message string) {
+	if c.GetType() == ctx.HTTPType {
+		c.ResponseWriter.Header().Set(
+			"WWW-Authenticate",
+			"Basic realm="+strconv.Quote(instance.BasicAuthService.Realm)+`, charset="UTF-8"`,
+		)
+	}
+
+	panic(exception.UnauthorizedException(message))
+}
@@ -0,0 +1,72 @@
+package basic
+
+import (
+	"errors"
+
+	"github.com/dangduoc08/gogo/core"
+	"github.com/dangduoc08/gogo/modules/config"
+)
+
+type (
+	BasicAuthConfigLoadFn = func(config.ConfigService) *BasicAuthModuleOptions
+)
+
+type BasicAuthModuleOptions struct {
+	IsGlobal bool
+	Realm    string
+
+	// passwords of usernames, e.g. ParseUsers of env.
+	// Validator takes precedence,
+	// e.g. to check hashed passwords of user table
+	Users     StaticUsers
+	Validator Validator
+}
+
+func loadBasicAuthOptions(opts *BasicAuthModuleOptions) *BasicAuthModuleOptions {
+	if opts == nil {
+		opts = &BasicAuthModuleOptions{}
+	}
+
+	basicAuthOptions := &BasicAuthModuleOptions{
+		IsGlobal:  opts.IsGlobal,
+		Realm:     opts.Realm,
+		Users:     opts.Users,
+		Validator: opts.Validator,
+	}
+
+	if basicAuthOptions.Realm == "" {
+		basicAuthOptions.Realm = "Restricted"
+	}
+
+	if basicAuthOptions.Validator == nil {
+		if len(basicAuthOptions.Users) == 0 {
+			panic(errors.New("basic auth users or validator are required"))
+		}
+		basicAuthOptions.Validator = basicAuthOptions.Users
+	}
+
+	return basicAuthOptions
+}
+
+func Register(opts *BasicAuthModuleOptions) *core.Module {
+	basicAuthOptions := loadBasicAuthOptions(opts)
+	basicAuthService := BasicAuthService{
+		Realm:     basicAuthOptions.Realm,
+		Validator: basicAuthOptions.Validator,
+	}
+
+	module := core.ModuleBuilder().
+		Providers(basicAuthService).
+		Build()
+
+	module.IsGlobal = basicAuthOptions.IsGlobal
+	return module
+}
+
+// RegisterWithConfig reads realm and users
+// from globally registered ConfigService
+func RegisterWithConfig(load BasicAuthConfigLoadFn) func(config.ConfigService) *core.Module {
+	return func(configService config.ConfigService) *core.Module {
+		return Register(load(configService))
+	}
+}
@@ -0,0 +1,34 @@
+package basic
+
+import (
+	"errors"
+
+	"github.com/dangduoc08/gogo/common"
+	"github.com/dangduoc08/gogo/ctx"
+)
+
+type principalKey struct{}
+
+// Principal is user of Basic credentials,
+// ID is username of Authorization header
+// unless custom Validator resolves another identity
+type Principal struct {
+	ID         string
+	Attributes map[string]any
+}
+
+func (principal Principal) Transform(c *ctx.Context, metadata common.ArgumentMetadata) any {
+	resolvedPrincipal, ok := GetPrincipal(c)
+	if !ok {
+		panic(errors.New("basic auth guard was not applied"))
+	}
+
+	return resolvedPrincipal
+}
+
+// GetPrincipal returns user whose credentials were verified
+// by BasicAuthGuard, ok is false on routes without the guard
+func GetPrincipal(c *ctx.Context) (Principal, bool) {
+	principal, ok := c.Request.Context().Value(principalKey{}).(Principal)
+	return principal, ok
+}
@@ -0,0 +1,16 @@
+package basic
+
+import (
+	"github.com/dangduoc08/gogo/core"
+)
+
+// BasicAuthService holds realm of challenge
+// and Validator of BasicAuthGuard
+type BasicAuthService struct {
+	Realm     string
+	Validator Validator
+}
+
+func (basicAuthService BasicAuthService) NewProvider() core.Provider {
+	return basicAuthService
+}
@@ -0,0 +1,76 @@
+package basic
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"strings"
+
+	"github.com/dangduoc08/gogo/ctx"
+)
+
+type Validator interface {
+	Validate(c *ctx.Context, username, password string) (Principal, bool)
+}
+
+// ValidateFunc adapts callback to Validator
+type ValidateFunc func(c *ctx.Context, username, password string) (Principal, bool)
+
+func (validateFunc ValidateFunc) Validate(c *ctx.Context, username, password string) (Principal, bool) {
+	return validateFunc(c, username, password)
+}
+
+// StaticUsers validates credentials,
+// users is map of username to password
+type StaticUsers map[string]string
+
+// Validate compares credentials with all users in constant time
+// to not leak whether username exists,
+// empty passwords are never matched
+func (staticUsers StaticUsers) Validate(c *ctx.Context, username, password string) (Principal, bool) {
+	if password == "" {
+		return Principal{}, false
+	}
+
+	credentialHash := hashCredential(username, password)
+	isMatched := 0
+
+	for staticUsername, staticPassword := range staticUsers {
+		if staticPassword == "" {
+			continue
+		}
+
+		staticCredentialHash := hashCredential(staticUsername, staticPassword)
+		isMatched |= subtle.ConstantTimeCompare(credentialHash, staticCredentialHash)
+	}
+
+	if isMatched == 0 {
+		return Principal{}, false
+	}
+
+	return Principal{ID: username}, true
+}
+
+// ParseUsers parses users of
+// "username:password,username:password" format,
+// e.g. from ConfigService.
+// users without password are skipped
+func ParseUsers(s string) StaticUsers {
+	staticUsers := StaticUsers{}
+	for _, pair := range strings.Split(s, ",") {
+		username, password, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if ok && username != "" && password != "" {
+			staticUsers[username] = password
+		}
+	}
+
+	return staticUsers
+}
+
+func hashCredential(username, password string) []byte {
+	hash := sha256.New()
+	hash.Write([]byte(username))
+	hash.Write([]byte{0})
+	hash.Write([]byte(password))
+
+	return hash.Sum(nil)
+}
@@ -0,0 +1,145 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"hash"
+	"math/big"
+)
+
+const (
+	HS256 = "HS256"
+	HS384 = "HS384"
+	HS512 = "HS512"
+	RS256 = "RS256"
+	ES256 = "ES256"
+	EdDSA = "EdDSA"
+)
+
+func sign(algorithm string, key Key, data []byte) ([]byte, error) {
+	switch algorithm {
+	case HS256, HS384, HS512:
+		if len(key.Secret) == 0 {
+			return nil, ErrKeyInvalid
+		}
+
+		mac := hmac.New(hashFunc(algorithm), key.Secret)
+		mac.Write(data)
+		return mac.Sum(nil), nil
+
+	case RS256:
+		privateKey, ok := key.PrivateKey.(*rsa.PrivateKey)
+		if !ok {
+			return nil, ErrKeyInvalid
+		}
+
+		digest := sha256.Sum256(data)
+		return rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, digest[:])
+
+	case ES256:
+		privateKey, ok := key.PrivateKey.(*ecdsa.PrivateKey)
+		if !ok || privateKey.Curve != elliptic.P256() {
+			return nil, ErrKeyInvalid
+		}
+
+		digest := sha256.Sum256(data)
+		r, s, err := ecdsa.Sign(rand.Reader, privateKey, digest[:])
+		if err != nil {
+			return nil, err
+		}
+
+		// signature is R || S
+		// with fixed 32 bytes each
+		signature := make([]byte, 64)
+		r.FillBytes(signature[:32])
+		s.FillBytes(signature[32:])
+		return signature, nil
+
+	case EdDSA:
+		privateKey, ok := key.PrivateKey.(ed25519.PrivateKey)
+		if !ok {
+			return nil, ErrKeyInvalid
+		}
+
+		return ed25519.Sign(privateKey, data), nil
+	}
+
+	return nil, ErrAlgorithmUnsupported
+}
+
+func verify(algorithm string, key Key, data, signature []byte) error {
+	switch algorithm {
+	case HS256, HS384, HS512:
+		if len(key.Secret) == 0 {
+			return ErrKeyInvalid
+		}
+
+		mac := hmac.New(hashFunc(algorithm), key.Secret)
+		mac.Write(data)
+		if !hmac.Equal(mac.Sum(nil), signature) {
+			return ErrSignatureInvalid
+		}
+		return nil
+
+	case RS256:
+		publicKey, ok := key.publicKey().(*rsa.PublicKey)
+		if !ok {
+			return ErrKeyInvalid
+		}
+
+		digest := sha256.Sum256(data)
+		if rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest[:], signature) != nil {
+			return ErrSignatureInvalid
+		}
+		return nil
+
+	case ES256:
+		publicKey, ok := key.publicKey().(*ecdsa.PublicKey)
+		if !ok || publicKey.Curve != elliptic.P256() {
+			return ErrKeyInvalid
+		}
+
+		if len(signature) != 64 {
+			return ErrSignatureInvalid
+		}
+
+		digest := sha256.Sum256(data)
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+		if !ecdsa.Verify(publicKey, digest[:], r, s) {
+			return ErrSignatureInvalid
+		}
+		return nil
+
+	case EdDSA:
+		publicKey, ok := key.publicKey().(ed25519.PublicKey)
+		if !ok {
+			return ErrKeyInvalid
+		}
+
+		if !ed25519.Verify(publicKey, data, signature) {
+			return ErrSignatureInvalid
+		}
+		return nil
+	}
+
+	return ErrAlgorithmUnsupported
+}
+
+func hashFunc(algorithm string) func() hash.Hash {
+	switch algorithm {
+	case HS384:
+		return sha512.New384
+	case HS512:
+		return sha512.New
+	}
+
+	return sha256.New
+}
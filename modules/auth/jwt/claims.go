@@ -0,0 +1,97 @@
+package jwt
+
+import (
+	"errors"
+	"time"
+
+	"github.com/dangduoc08/gogo/common"
+	"github.com/dangduoc08/gogo/ctx"
+)
+
+type Claims map[string]any
+
+type payloadKey struct{}
+
+// Payload is injectable argument of REST and WS handlers,
+// it holds claims which were verified by JWTGuard
+type Payload struct {
+	Claims
+	Token string
+}
+
+func (payload Payload) Transform(c *ctx.Context, metadata common.ArgumentMetadata) any {
+	verifiedPayload, ok := GetPayload(c)
+	if !ok {
+		panic(errors.New("jwt guard was not applied"))
+	}
+
+	return verifiedPayload
+}
+
+// GetPayload returns payload verified by JWTGuard
+// for current request or WS connection
+func GetPayload(c *ctx.Context) (Payload, bool) {
+	payload, ok := c.Request.Context().Value(payloadKey{}).(Payload)
+	return payload, ok
+}
+
+func (claims Claims) GetString(k string) string {
+	v, _ := claims[k].(string)
+	return v
+}
+
+func (claims Claims) GetTime(k string) time.Time {
+	switch v := claims[k].(type) {
+	case float64:
+		return time.Unix(int64(v), 0)
+	case int64:
+		return time.Unix(v, 0)
+	case int:
+		return time.Unix(int64(v), 0)
+	case time.Time:
+		return v
+	}
+
+	return time.Time{}
+}
+
+func (claims Claims) Subject() string {
+	return claims.GetString("sub")
+}
+
+func (claims Claims) Issuer() string {
+	return claims.GetString("iss")
+}
+
+// Audience returns aud claim
+// which can be either string or string array
+func (claims Claims) Audience() []string {
+	switch aud := claims["aud"].(type) {
+	case string:
+		return []string{aud}
+	case []string:
+		return aud
+	case []any:
+		audience := []string{}
+		for _, v := range aud {
+			if s, ok := v.(string); ok {
+				audience = append(audience, s)
+			}
+		}
+		return audience
+	}
+
+	return nil
+}
+
+func (claims Claims) ExpiresAt() time.Time {
+	return claims.GetTime("exp")
+}
+
+func (claims Claims) NotBefore() time.Time {
+	return claims.GetTime("nbf")
+}
+
+func (claims Claims) IssuedAt() time.Time {
+	return claims.GetTime("iat")
+}
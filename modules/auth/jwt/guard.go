@@ -0,0 +1,61 @@
+package jwt
+
+import (
+	"context"
+	"strings"
+
+	"github.com/dangduoc08/gogo/ctx"
+	"github.com/dangduoc08/gogo/exception"
+)
+
+// JWTGuard verifies bearer token of Authorization header,
+// WS handshakes may send token by access_token query instead.
+// verified claims are injected into handlers by Payload
+type JWTGuard struct {
+	JWTService JWTService
+}
+
+func (instance JWTGuard) CanActivate(c *ctx.Context) bool {
+	token := getBearerToken(c)
+	if token == "" {
+		instance.reject(c, "", "Missing bearer token")
+	}
+
+	claims, err := instance.JWTService.Verify(token)
+	if err != nil {
+		instance.reject(c, "invalid_token", "Invalid token")
+	}
+
+	newCtx := context.WithValue(c.Request.Context(), payloadKey{}, Payload{
+		Claims: claims,
+		Token:  token,
+	})
+	c.Request = c.Request.WithContext(newCtx)
+
+	return true
+}
+
+func (instance JWTGuard) reject(c *ctx.Context, errorCode, message string) {
+	if c.GetType() == ctx.HTTPType {
+		wwwAuthenticate := "Bearer"
+		if errorCode != "" {
+			wwwAuthenticate += ` error="` + errorCode + `"`
+		}
+		c.ResponseWriter.Header().Set("WWW-Authenticate", wwwAuthenticate)
+	}
+
+	panic(exception.UnauthorizedException(message))
+}
+
+func getBearerToken(c *ctx.Context) string {
+	scheme, token, ok := strings.Cut(c.Request.Header.Get("Authorization"), " ")
+	if ok && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(token)
+	}
+
+	if c.GetType() == ctx.WSType {
+		return c.Request.URL.Query().Get("access_token")
+	}
+
+	return ""
+}
@@ -0,0 +1,274 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// JWK is JSON Web Key of RSA, EC, OKP or oct type
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	Use string `json:"use,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+	K   string `json:"k,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+func (jwk JWK) Key() (Key, error) {
+	key := Key{
+		ID:        jwk.Kid,
+		Algorithm: jwk.Alg,
+	}
+
+	switch jwk.Kty {
+	case "RSA":
+		n, err := decodeSegment(jwk.N)
+		if err != nil {
+			return key, err
+		}
+
+		e, err := decodeSegment(jwk.E)
+		if err != nil {
+			return key, err
+		}
+
+		key.PublicKey = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+
+	case "EC":
+		if jwk.Crv != "P-256" {
+			return key, fmt.Errorf("jwt: unsupported curve %v", jwk.Crv)
+		}
+
+		x, err := decodeSegment(jwk.X)
+		if err != nil {
+			return key, err
+		}
+
+		y, err := decodeSegment(jwk.Y)
+		if err != nil {
+			return key, err
+		}
+
+		key.PublicKey = &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}
+
+	case "OKP":
+		if jwk.Crv != "Ed25519" {
+			return key, fmt.Errorf("jwt: unsupported curve %v", jwk.Crv)
+		}
+
+		x, err := decodeSegment(jwk.X)
+		if err != nil {
+			return key, err
+		}
+
+		if len(x) != ed25519.PublicKeySize {
+			return key, ErrKeyInvalid
+		}
+		key.PublicKey = ed25519.PublicKey(x)
+
+	case "oct":
+		k, err := decodeSegment(jwk.K)
+		if err != nil {
+			return key, err
+		}
+		key.Secret = k
+
+	default:
+		return key, fmt.Errorf("jwt: unsupported key type %v", jwk.Kty)
+	}
+
+	if key.Algorithm == "" {
+		if key.Secret != nil {
+			key.Algorithm = HS256
+		} else {
+			key.Algorithm = inferAlgorithm(key.PublicKey)
+		}
+	}
+
+	return key, nil
+}
+
+// ParseJWKS parses JWKS document,
+// keys which are not used for signature
+// or not supported are skipped
+func ParseJWKS(data []byte) ([]Key, error) {
+	jwks := JWKS{}
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		return nil, err
+	}
+
+	keys := []Key{}
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		if key, err := jwk.Key(); err == nil {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys, nil
+}
+
+func LoadJWKSFile(path string) ([]Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseJWKS(data)
+}
+
+// RemoteJWKS fetches keys from JWKS URL
+// and caches them for TTL.
+// unknown key IDs trigger refetching
+// at most once per MinRefreshInterval,
+// failed fetches are retried after MinRefreshInterval too
+type RemoteJWKS struct {
+	URL                string
+	TTL                time.Duration
+	MinRefreshInterval time.Duration
+	Client             *http.Client
+
+	mu        sync.Mutex
+	keys      []Key
+	fetchedAt time.Time
+	failedAt  time.Time
+	err       error
+	call      *jwksCall
+}
+
+// jwksCall is shared by concurrent callers
+// while keys are being fetched
+type jwksCall struct {
+	done chan struct{}
+	keys []Key
+	err  error
+}
+
+func NewRemoteJWKS(url string, ttl time.Duration) *RemoteJWKS {
+	return &RemoteJWKS{
+		URL:                url,
+		TTL:                ttl,
+		MinRefreshInterval: 10 * time.Second,
+		Client:             &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Keys returns cached keys,
+// stale keys are still returned if refetching failed
+func (remoteJWKS *RemoteJWKS) Keys(kid string) ([]Key, error) {
+	remoteJWKS.mu.Lock()
+
+	sinceFetched := time.Since(remoteJWKS.fetchedAt)
+	isExpired := remoteJWKS.fetchedAt.IsZero() || sinceFetched >= remoteJWKS.TTL
+	isRotated := kid != "" &&
+		!hasKeyID(remoteJWKS.keys, kid) &&
+		sinceFetched >= remoteJWKS.MinRefreshInterval
+	isBackingOff := time.Since(remoteJWKS.failedAt) < remoteJWKS.MinRefreshInterval
+
+	if !(isExpired || isRotated) || isBackingOff {
+		defer remoteJWKS.mu.Unlock()
+
+		if len(remoteJWKS.keys) == 0 && remoteJWKS.err != nil {
+			return nil, remoteJWKS.err
+		}
+
+		return remoteJWKS.keys, nil
+	}
+
+	// only one fetch is in flight,
+	// the others wait for its result
+	if call := remoteJWKS.call; call != nil {
+		remoteJWKS.mu.Unlock()
+		<-call.done
+
+		return call.keys, call.err
+	}
+
+	call := &jwksCall{
+		done: make(chan struct{}),
+	}
+	remoteJWKS.call = call
+	remoteJWKS.mu.Unlock()
+
+	keys, err := remoteJWKS.fetch()
+
+	remoteJWKS.mu.Lock()
+	if err != nil {
+		remoteJWKS.failedAt = time.Now()
+		remoteJWKS.err = err
+	} else {
+		remoteJWKS.keys = keys
+		remoteJWKS.fetchedAt = time.Now()
+		remoteJWKS.failedAt = time.Time{}
+		remoteJWKS.err = nil
+	}
+
+	if len(remoteJWKS.keys) == 0 && err != nil {
+		call.err = err
+	} else {
+		call.keys = remoteJWKS.keys
+	}
+	remoteJWKS.call = nil
+	remoteJWKS.mu.Unlock()
+	close(call.done)
+
+	return call.keys, call.err
+}
+
+func (remoteJWKS *RemoteJWKS) fetch() ([]Key, error) {
+	res, err := remoteJWKS.Client.Get(remoteJWKS.URL)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.New("jwt: failed to fetch JWKS, status " + res.Status)
+	}
+
+	jwks := json.RawMessage{}
+	if err := json.NewDecoder(res.Body).Decode(&jwks); err != nil {
+		return nil, err
+	}
+
+	return ParseJWKS(jwks)
+}
+
+func hasKeyID(keys []Key, kid string) bool {
+	for _, key := range keys {
+		if key.ID == kid {
+			return true
+		}
+	}
+
+	return false
+}
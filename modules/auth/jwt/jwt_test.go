@@ -0,0 +1,208 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dangduoc08/gogo/utils"
+)
+
+func TestJWTServiceAlgorithms(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecdsaKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	_, ed25519Key, _ := ed25519.GenerateKey(rand.Reader)
+
+	keys := []Key{
+		{Algorithm: HS256, Secret: []byte("secret")},
+		{Algorithm: HS384, Secret: []byte("secret")},
+		{Algorithm: HS512, Secret: []byte("secret")},
+		{Algorithm: RS256, PrivateKey: rsaKey},
+		{Algorithm: ES256, PrivateKey: ecdsaKey},
+		{Algorithm: EdDSA, PrivateKey: ed25519Key},
+	}
+
+	for _, key := range keys {
+		jwtService := JWTService{
+			Keys:      []Key{key},
+			ExpiresIn: time.Minute,
+		}
+
+		token, err := jwtService.Sign(Claims{"sub": "gogo"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		claims, err := jwtService.Verify(token)
+		if err != nil || claims.Subject() != "gogo" {
+			t.Errorf(utils.ErrorMessage(err, nil, key.Algorithm+" token should be verified"))
+		}
+
+		tamperedToken := token[:len(token)-4] + "AAAA"
+		if _, err := jwtService.Verify(tamperedToken); err == nil {
+			t.Errorf(utils.ErrorMessage(err, ErrSignatureInvalid, key.Algorithm+" tampered token should be rejected"))
+		}
+	}
+}
+
+func TestJWTServiceAlgorithmConfusion(t *testing.T) {
+	jwtService := JWTService{
+		Keys: []Key{{Algorithm: HS256, Secret: []byte("secret")}},
+	}
+
+	noneToken := encodeSegment([]byte(`{"alg":"none"}`)) + "." + encodeSegment([]byte(`{"sub":"gogo"}`)) + "."
+	if _, err := jwtService.Verify(noneToken); err != ErrAlgorithmUnsupported {
+		t.Errorf(utils.ErrorMessage(err, ErrAlgorithmUnsupported, "none algorithm should be rejected"))
+	}
+
+	hs512Token, _ := encode(Header{Algorithm: HS512}, Claims{"sub": "gogo"}, Key{Secret: []byte("secret")})
+	if _, err := jwtService.Verify(hs512Token); err != ErrTokenUnverifiable {
+		t.Errorf(utils.ErrorMessage(err, ErrTokenUnverifiable, "token of other algorithm should be rejected"))
+	}
+}
+
+func TestJWTServiceValidate(t *testing.T) {
+	jwtService := JWTService{
+		Issuer:    "gogo",
+		Audience:  []string{"api"},
+		ClockSkew: 30 * time.Second,
+	}
+	now := time.Now()
+
+	cases := []struct {
+		claims   Claims
+		expected error
+		msg      string
+	}{
+		{Claims{"iss": "gogo", "aud": "api", "exp": float64(now.Add(-10 * time.Second).Unix())}, nil, "token expired within clock skew should be valid"},
+		{Claims{"iss": "gogo", "aud": "api", "exp": float64(now.Add(-time.Minute).Unix())}, ErrTokenExpired, "token should be expired"},
+		{Claims{"iss": "gogo", "aud": "api", "nbf": float64(now.Add(time.Minute).Unix())}, ErrTokenNotValidYet, "token should not be valid yet"},
+		{Claims{"iss": "other", "aud": "api"}, ErrIssuerInvalid, "issuer should be invalid"},
+		{Claims{"iss": "gogo", "aud": []any{"web", "api"}}, nil, "audience array should be valid"},
+		{Claims{"iss": "gogo", "aud": "web"}, ErrAudienceInvalid, "audience should be invalid"},
+		{Claims{"iss": "gogo", "aud": "api", "exp": "tomorrow"}, ErrTokenMalformed, "exp should be NumericDate"},
+	}
+
+	for _, testCase := range cases {
+		if err := jwtService.validate(testCase.claims, now); err != testCase.expected {
+			t.Errorf(utils.ErrorMessage(err, testCase.expected, testCase.msg))
+		}
+	}
+}
+
+func TestParseJWKS(t *testing.T) {
+	ecdsaKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	ed25519PublicKey, ed25519Key, _ := ed25519.GenerateKey(rand.Reader)
+
+	jwks, _ := json.Marshal(JWKS{
+		Keys: []JWK{
+			{
+				Kty: "EC",
+				Kid: "ec",
+				Crv: "P-256",
+				X:   encodeSegment(ecdsaKey.X.FillBytes(make([]byte, 32))),
+				Y:   encodeSegment(ecdsaKey.Y.FillBytes(make([]byte, 32))),
+			},
+			{
+				Kty: "OKP",
+				Kid: "ed",
+				Crv: "Ed25519",
+				X:   encodeSegment(ed25519PublicKey),
+			},
+			{
+				Kty: "RSA",
+				Kid: "enc",
+				Use: "enc",
+			},
+		},
+	})
+
+	keys, err := ParseJWKS(jwks)
+	if err != nil || len(keys) != 2 {
+		t.Fatalf(utils.ErrorMessage(len(keys), 2, "signature keys should be parsed"))
+	}
+
+	jwtService := JWTService{Keys: keys}
+	signer := JWTService{Keys: []Key{{ID: "ed", Algorithm: EdDSA, PrivateKey: ed25519Key}}}
+	token, _ := signer.Sign(Claims{"sub": "gogo"})
+
+	if claims, err := jwtService.Verify(token); err != nil || claims.Subject() != "gogo" {
+		t.Errorf(utils.ErrorMessage(err, nil, "token should be verified by JWKS key"))
+	}
+
+	signer.Keys[0].ID = "ec"
+	token, _ = signer.Sign(Claims{"sub": "gogo"})
+	if _, err := jwtService.Verify(token); err != ErrTokenUnverifiable {
+		t.Errorf(utils.ErrorMessage(err, ErrTokenUnverifiable, "token should not be verified by key of other ID"))
+	}
+
+	if !strings.HasPrefix(token, encodeSegment([]byte(`{"alg":"EdDSA","typ":"JWT","kid":"ec"}`))) {
+		t.Errorf(utils.ErrorMessage(token, "header with kid", "kid should be set"))
+	}
+}
+
+func TestRemoteJWKS(t *testing.T) {
+	var fetchedTotal atomic.Int32
+	isFailed := atomic.Bool{}
+	isFailed.Store(true)
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetchedTotal.Add(1)
+		<-release
+
+		if isFailed.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		json.NewEncoder(w).Encode(JWKS{
+			Keys: []JWK{{Kty: "oct", Kid: "hs", K: encodeSegment([]byte("secret"))}},
+		})
+	}))
+	defer server.Close()
+
+	remoteJWKS := NewRemoteJWKS(server.URL, time.Minute)
+	remoteJWKS.MinRefreshInterval = 50 * time.Millisecond
+
+	// concurrent callers share one fetch
+	wg := sync.WaitGroup{}
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := remoteJWKS.Keys("hs"); err == nil {
+				t.Errorf(utils.ErrorMessage(err, "fetch error", "failed fetch should return error"))
+			}
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if total := fetchedTotal.Load(); total != 1 {
+		t.Errorf(utils.ErrorMessage(total, 1, "JWKS should be fetched once"))
+	}
+
+	// failed fetch is not retried
+	// until MinRefreshInterval passed
+	isFailed.Store(false)
+	if _, err := remoteJWKS.Keys("hs"); err == nil || fetchedTotal.Load() != 1 {
+		t.Errorf(utils.ErrorMessage(fetchedTotal.Load(), 1, "retry should be rate limited"))
+	}
+
+	time.Sleep(remoteJWKS.MinRefreshInterval)
+	if keys, err := remoteJWKS.Keys("hs"); err != nil || len(keys) != 1 || fetchedTotal.Load() != 2 {
+		t.Errorf(utils.ErrorMessage(err, nil, "keys should be fetched after MinRefreshInterval"))
+	}
+}
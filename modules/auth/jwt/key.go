@@ -0,0 +1,104 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+)
+
+// Key signs and verifies tokens of its algorithm,
+// Secret is used by HMAC algorithms,
+// PrivateKey and PublicKey are used by the others.
+// PublicKey is derived from PrivateKey if empty
+type Key struct {
+	ID         string
+	Algorithm  string
+	Secret     []byte
+	PrivateKey crypto.PrivateKey
+	PublicKey  crypto.PublicKey
+}
+
+func (key Key) publicKey() crypto.PublicKey {
+	if key.PublicKey != nil {
+		return key.PublicKey
+	}
+
+	if signer, ok := key.PrivateKey.(crypto.Signer); ok {
+		return signer.Public()
+	}
+
+	return nil
+}
+
+func (key Key) canSign() bool {
+	return len(key.Secret) > 0 || key.PrivateKey != nil
+}
+
+// ParsePrivateKey parses PEM encoded
+// PKCS #8, PKCS #1 or SEC 1 private key
+func ParsePrivateKey(data []byte) (crypto.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("jwt: invalid PEM private key")
+	}
+
+	if privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		return privateKey, nil
+	}
+
+	if privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return privateKey, nil
+	}
+
+	if privateKey, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return privateKey, nil
+	}
+
+	return nil, errors.New("jwt: unsupported private key")
+}
+
+// ParsePublicKey parses PEM encoded
+// PKIX, PKCS #1 public key or certificate
+func ParsePublicKey(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("jwt: invalid PEM public key")
+	}
+
+	if publicKey, err := x509.ParsePKIXPublicKey(block.Bytes); err == nil {
+		return publicKey, nil
+	}
+
+	if publicKey, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return publicKey, nil
+	}
+
+	if certificate, err := x509.ParseCertificate(block.Bytes); err == nil {
+		return certificate.PublicKey, nil
+	}
+
+	return nil, errors.New("jwt: unsupported public key")
+}
+
+// inferAlgorithm returns algorithm
+// by type of asymmetric key
+func inferAlgorithm(key any) string {
+	switch k := key.(type) {
+	case *rsa.PrivateKey, *rsa.PublicKey:
+		return RS256
+	case *ecdsa.PrivateKey:
+		return ES256
+	case *ecdsa.PublicKey:
+		if k.Curve.Params().Name == "P-256" {
+			return ES256
+		}
+	case ed25519.PrivateKey, ed25519.PublicKey:
+		return EdDSA
+	}
+
+	return ""
+}
@@ -0,0 +1,169 @@
+package jwt
+
+import (
+	"errors"
+	"time"
+
+	"github.com/dangduoc08/gogo/core"
+	"github.com/dangduoc08/gogo/modules/config"
+)
+
+type (
+	JWTConfigLoadFn = func(config.ConfigService) *JWTModuleOptions
+)
+
+type JWTModuleOptions struct {
+	IsGlobal bool
+
+	// algorithm of signing key,
+	// inferred from PrivateKey or PublicKey if empty,
+	// otherwise HS256
+	Algorithm string
+	KeyID     string
+
+	// HMAC secret
+	// or PEM encoded keys
+	Secret     string
+	PrivateKey string
+	PublicKey  string
+
+	// additional keys,
+	// e.g. rotated keys which only verify tokens
+	Keys []Key
+
+	JWKSFile     string
+	JWKSURL      string
+	JWKSCacheTTL time.Duration
+
+	Issuer    string
+	Audience  []string
+	ExpiresIn time.Duration
+	ClockSkew time.Duration
+}
+
+func loadJWTOptions(opts *JWTModuleOptions) *JWTModuleOptions {
+	if opts == nil {
+		opts = &JWTModuleOptions{}
+	}
+
+	jwtOptions := &JWTModuleOptions{
+		IsGlobal:     opts.IsGlobal,
+		Algorithm:    opts.Algorithm,
+		KeyID:        opts.KeyID,
+		Secret:       opts.Secret,
+		PrivateKey:   opts.PrivateKey,
+		PublicKey:    opts.PublicKey,
+		Keys:         opts.Keys,
+		JWKSFile:     opts.JWKSFile,
+		JWKSURL:      opts.JWKSURL,
+		JWKSCacheTTL: opts.JWKSCacheTTL,
+		Issuer:       opts.Issuer,
+		Audience:     opts.Audience,
+		ExpiresIn:    opts.ExpiresIn,
+		ClockSkew:    opts.ClockSkew,
+	}
+
+	if jwtOptions.JWKSCacheTTL <= 0 {
+		jwtOptions.JWKSCacheTTL = time.Hour
+	}
+
+	if jwtOptions.ExpiresIn <= 0 {
+		jwtOptions.ExpiresIn = time.Hour
+	}
+
+	return jwtOptions
+}
+
+func loadKeys(opts *JWTModuleOptions) []Key {
+	keys := []Key{}
+
+	if opts.Secret != "" || opts.PrivateKey != "" || opts.PublicKey != "" {
+		key := Key{
+			ID:        opts.KeyID,
+			Algorithm: opts.Algorithm,
+		}
+
+		if opts.Secret != "" {
+			key.Secret = []byte(opts.Secret)
+		}
+
+		if opts.PrivateKey != "" {
+			privateKey, err := ParsePrivateKey([]byte(opts.PrivateKey))
+			if err != nil {
+				panic(err)
+			}
+			key.PrivateKey = privateKey
+		}
+
+		if opts.PublicKey != "" {
+			publicKey, err := ParsePublicKey([]byte(opts.PublicKey))
+			if err != nil {
+				panic(err)
+			}
+			key.PublicKey = publicKey
+		}
+
+		keys = append(keys, key)
+	}
+
+	keys = append(keys, opts.Keys...)
+
+	if opts.JWKSFile != "" {
+		jwksKeys, err := LoadJWKSFile(opts.JWKSFile)
+		if err != nil {
+			panic(err)
+		}
+		keys = append(keys, jwksKeys...)
+	}
+
+	for i, key := range keys {
+		if key.Algorithm != "" {
+			continue
+		}
+
+		if len(key.Secret) > 0 {
+			keys[i].Algorithm = HS256
+		} else if key.PrivateKey != nil {
+			keys[i].Algorithm = inferAlgorithm(key.PrivateKey)
+		} else {
+			keys[i].Algorithm = inferAlgorithm(key.PublicKey)
+		}
+	}
+
+	return keys
+}
+
+func Register(opts *JWTModuleOptions) *core.Module {
+	jwtOptions := loadJWTOptions(opts)
+	jwtService := JWTService{
+		Keys:      loadKeys(jwtOptions),
+		Issuer:    jwtOptions.Issuer,
+		Audience:  jwtOptions.Audience,
+		ExpiresIn: jwtOptions.ExpiresIn,
+		ClockSkew: jwtOptions.ClockSkew,
+	}
+
+	if jwtOptions.JWKSURL != "" {
+		jwtService.RemoteJWKS = NewRemoteJWKS(jwtOptions.JWKSURL, jwtOptions.JWKSCacheTTL)
+	}
+
+	if len(jwtService.Keys) == 0 && jwtService.RemoteJWKS == nil {
+		panic(errors.New("jwt keys are required"))
+	}
+
+	module := core.ModuleBuilder().
+		Providers(jwtService).
+		Build()
+
+	module.IsGlobal = jwtOptions.IsGlobal
+	return module
+}
+
+// RegisterWithConfig returns dynamic module
+// which loads options from ConfigService,
+// config module must be registered globally
+func RegisterWithConfig(load JWTConfigLoadFn) func(config.ConfigService) *core.Module {
+	return func(configService config.ConfigService) *core.Module {
+		return Register(load(configService))
+	}
+}
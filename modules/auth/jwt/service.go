@@ -0,0 +1,188 @@
+package jwt
+
+import (
+	"time"
+
+	"github.com/dangduoc08/gogo/core"
+)
+
+type JWTService struct {
+	Keys       []Key
+	RemoteJWKS *RemoteJWKS
+	Issuer     string
+	Audience   []string
+	ExpiresIn  time.Duration
+	ClockSkew  time.Duration
+}
+
+func (jwtService JWTService) NewProvider() core.Provider {
+	return jwtService
+}
+
+// Sign signs claims by the first signing key,
+// iat, exp, iss and aud are filled
+// by module options if absent
+func (jwtService JWTService) Sign(claims Claims) (string, error) {
+	key, ok := jwtService.signingKey()
+	if !ok {
+		return "", ErrKeyInvalid
+	}
+
+	now := time.Now()
+	signedClaims := Claims{}
+	for k, v := range claims {
+		signedClaims[k] = v
+	}
+
+	if _, ok := signedClaims["iat"]; !ok {
+		signedClaims["iat"] = now.Unix()
+	}
+
+	if _, ok := signedClaims["exp"]; !ok && jwtService.ExpiresIn > 0 {
+		signedClaims["exp"] = now.Add(jwtService.ExpiresIn).Unix()
+	}
+
+	if _, ok := signedClaims["iss"]; !ok && jwtService.Issuer != "" {
+		signedClaims["iss"] = jwtService.Issuer
+	}
+
+	if _, ok := signedClaims["aud"]; !ok && len(jwtService.Audience) > 0 {
+		if len(jwtService.Audience) == 1 {
+			signedClaims["aud"] = jwtService.Audience[0]
+		} else {
+			signedClaims["aud"] = jwtService.Audience
+		}
+	}
+
+	// time values are encoded
+	// as NumericDate
+	for k, v := range signedClaims {
+		if t, ok := v.(time.Time); ok {
+			signedClaims[k] = t.Unix()
+		}
+	}
+
+	return encode(Header{
+		Algorithm: key.Algorithm,
+		Type:      "JWT",
+		KeyID:     key.ID,
+	}, signedClaims, key)
+}
+
+// Verify verifies signature by key of token algorithm
+// then validates exp, nbf, iss and aud claims
+func (jwtService JWTService) Verify(token string) (Claims, error) {
+	header, claims, signingInput, signature, err := decode(token)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := jwtService.verifyingKeys(header)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keys) == 0 {
+		return nil, ErrTokenUnverifiable
+	}
+
+	err = ErrSignatureInvalid
+	for _, key := range keys {
+		if err = verify(header.Algorithm, key, []byte(signingInput), signature); err == nil {
+			break
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := jwtService.validate(claims, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+func (jwtService JWTService) signingKey() (Key, bool) {
+	for _, key := range jwtService.Keys {
+		if key.canSign() {
+			return key, true
+		}
+	}
+
+	return Key{}, false
+}
+
+// keys must have the same algorithm as token
+// to prevent algorithm confusion,
+// keys without ID match any key ID
+func (jwtService JWTService) verifyingKeys(header Header) ([]Key, error) {
+	if header.Algorithm == "" || header.Algorithm == "none" {
+		return nil, ErrAlgorithmUnsupported
+	}
+
+	keys := jwtService.Keys
+	if jwtService.RemoteJWKS != nil {
+		remoteKeys, err := jwtService.RemoteJWKS.Keys(header.KeyID)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(append([]Key{}, keys...), remoteKeys...)
+	}
+
+	verifyingKeys := []Key{}
+	for _, key := range keys {
+		if key.Algorithm != header.Algorithm {
+			continue
+		}
+
+		if header.KeyID != "" && key.ID != "" && key.ID != header.KeyID {
+			continue
+		}
+
+		verifyingKeys = append(verifyingKeys, key)
+	}
+
+	return verifyingKeys, nil
+}
+
+func (jwtService JWTService) validate(claims Claims, now time.Time) error {
+	for _, k := range []string{"exp", "nbf", "iat"} {
+		if _, ok := claims[k]; ok {
+			if _, ok := claims[k].(float64); !ok {
+				return ErrTokenMalformed
+			}
+		}
+	}
+
+	if _, ok := claims["exp"]; ok && !now.Before(claims.ExpiresAt().Add(jwtService.ClockSkew)) {
+		return ErrTokenExpired
+	}
+
+	if _, ok := claims["nbf"]; ok && now.Add(jwtService.ClockSkew).Before(claims.NotBefore()) {
+		return ErrTokenNotValidYet
+	}
+
+	if jwtService.Issuer != "" && claims.Issuer() != jwtService.Issuer {
+		return ErrIssuerInvalid
+	}
+
+	if len(jwtService.Audience) > 0 && !isAudienceAllowed(claims.Audience(), jwtService.Audience) {
+		return ErrAudienceInvalid
+	}
+
+	return nil
+}
+
+func isAudienceAllowed(audience, allowedAudience []string) bool {
+	for _, aud := range audience {
+		for _, allowedAud := range allowedAudience {
+			if aud == allowedAud {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,86 @@
+package jwt
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+var (
+	ErrTokenMalformed       = errors.New("jwt: token is malformed")
+	ErrTokenExpired         = errors.New("jwt: token is expired")
+	ErrTokenNotValidYet     = errors.New("jwt: token is not valid yet")
+	ErrTokenUnverifiable    = errors.New("jwt: no key to verify token")
+	ErrSignatureInvalid     = errors.New("jwt: signature is invalid")
+	ErrIssuerInvalid        = errors.New("jwt: issuer is invalid")
+	ErrAudienceInvalid      = errors.New("jwt: audience is invalid")
+	ErrAlgorithmUnsupported = errors.New("jwt: algorithm is not supported")
+	ErrKeyInvalid           = errors.New("jwt: key is invalid for algorithm")
+)
+
+type Header struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ,omitempty"`
+	KeyID     string `json:"kid,omitempty"`
+}
+
+func encode(header Header, claims Claims, key Key) (string, error) {
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := encodeSegment(headerJSON) + "." + encodeSegment(claimsJSON)
+	signature, err := sign(header.Algorithm, key, []byte(signingInput))
+	if err != nil {
+		return "", err
+	}
+
+	return signingInput + "." + encodeSegment(signature), nil
+}
+
+// decode parses token without verifying
+func decode(token string) (Header, Claims, string, []byte, error) {
+	header := Header{}
+	claims := Claims{}
+
+	segments := strings.Split(token, ".")
+	if len(segments) != 3 {
+		return header, claims, "", nil, ErrTokenMalformed
+	}
+
+	headerJSON, err := decodeSegment(segments[0])
+	if err != nil || json.Unmarshal(headerJSON, &header) != nil {
+		return header, claims, "", nil, ErrTokenMalformed
+	}
+
+	claimsJSON, err := decodeSegment(segments[1])
+	if err != nil {
+		return header, claims, "", nil, ErrTokenMalformed
+	}
+
+	if err := json.Unmarshal(claimsJSON, &claims); err != nil || claims == nil {
+		return header, claims, "", nil, ErrTokenMalformed
+	}
+
+	signature, err := decodeSegment(segments[2])
+	if err != nil {
+		return header, claims, "", nil, ErrTokenMalformed
+	}
+
+	return header, claims, segments[0] + "." + segments[1], signature, nil
+}
+
+func encodeSegment(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeSegment(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
@@ -15,6 +15,7 @@ type CacheModuler[T any] interface {
 	Set(string, T, time.Duration) // ex in milliseconds
 	Del(string) bool
 	Has(string) bool
+	Keys() []string
 	Clear() bool
 }
 
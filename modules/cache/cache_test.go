@@ -115,3 +115,40 @@ func TestLFUCache(t *testing.T) {
 //  - dll head.nex &{0xc0001706a0 0xc000170660 value_7}
 //  - dll tail.prev &{0xc0001706e0 0xc0001706a0 value_9}
 //  - dll tail &{<nil> 0xc0001706c0 value_10}
+
+func TestLFUCacheInvalidation(t *testing.T) {
+	cacheModule := New[string](CacheOpts{
+		Strategy: LFU,
+		Cap:      2,
+	})
+
+	cacheModule.Set("key_1", "value_1", -1)
+	cacheModule.Set("key_1", "value_1_1", -1)
+	cacheModule.Set("key_2", "value_2", -1)
+
+	if value, ok := cacheModule.Get("key_1"); !ok || value != "value_1_1" {
+		t.Errorf("Get output = %v; expected = %v", value, "value_1_1")
+	}
+
+	if !cacheModule.Has("key_2") {
+		t.Errorf("Has output = %v; expected = %v", false, true)
+	}
+
+	if !cacheModule.Del("key_2") || cacheModule.Has("key_2") {
+		t.Errorf("Del should delete key_2")
+	}
+
+	if cacheModule.Del("key_2") {
+		t.Errorf("Del output = %v; expected = %v", true, false)
+	}
+
+	cacheModule.Set("key_3", "value_3", -1)
+	if len(cacheModule.Keys()) != 2 {
+		t.Errorf("len(Keys) output = %v; expected = %v", len(cacheModule.Keys()), 2)
+	}
+
+	cacheModule.Clear()
+	if len(cacheModule.Keys()) != 0 || cacheModule.cap != 2 {
+		t.Errorf("Clear output cap = %v; expected = %v", cacheModule.cap, 2)
+	}
+}
@@ -0,0 +1,102 @@
+package cache
+
+import (
+	"io"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/dangduoc08/gogo/aggregation"
+	"github.com/dangduoc08/gogo/ctx"
+)
+
+// CacheInterceptor caches responses of GET requests.
+// bind it with different TTL per handler
+// or globally with App.BindGlobalInterceptors
+type CacheInterceptor struct {
+	CacheService CacheService
+
+	// fallback to TTL of cache module
+	TTL time.Duration
+
+	// request headers which response varies on
+	Headers []string
+}
+
+// cachedResponse keeps status and headers
+// which were set by handler along with data
+type cachedResponse struct {
+	code   int
+	header http.Header
+	data   any
+}
+
+// response headers which belong to single request
+var uncachedHeaders = []string{
+	"Set-Cookie",
+	"X-Cache",
+	"X-Request-ID",
+}
+
+func (instance CacheInterceptor) Intercept(c *ctx.Context, aggregation *aggregation.Aggregation) any {
+	if c.GetType() != ctx.HTTPType || c.Method != http.MethodGet {
+		return aggregation.Pipe()
+	}
+
+	key := GenResponseKey(c, instance.Headers)
+	if cached, ok := instance.CacheService.Get(key); ok {
+		if response, ok := cached.(cachedResponse); ok {
+			responseHeader := c.ResponseWriter.Header()
+			for k, v := range response.header {
+				responseHeader[k] = append([]string{}, v...)
+			}
+			responseHeader.Set("X-Cache", "HIT")
+			c.Status(response.code)
+
+			// main handler will be skipped
+			return response.data
+		}
+	}
+
+	c.ResponseWriter.Header().Set("X-Cache", "MISS")
+	return aggregation.Pipe(
+		aggregation.Consume(func(c *ctx.Context, data any) any {
+			if isCacheableStatus(c.Code) && isReplayable(data) {
+				header := c.ResponseWriter.Header().Clone()
+				for _, k := range uncachedHeaders {
+					header.Del(k)
+				}
+
+				instance.CacheService.Set(key, cachedResponse{
+					code:   c.Code,
+					header: header,
+					data:   data,
+				}, instance.TTL)
+			}
+			return data
+		}),
+	)
+}
+
+func isCacheableStatus(code int) bool {
+	return code >= http.StatusOK &&
+		code < http.StatusMultipleChoices &&
+		code != http.StatusPartialContent
+}
+
+// isReplayable reports whether data
+// can be returned again by cache hits,
+// readers and channels are drained by first response
+func isReplayable(data any) bool {
+	switch data.(type) {
+	case nil, io.Reader, ctx.Download, *ctx.Download:
+		return false
+	}
+
+	switch reflect.TypeOf(data).Kind() {
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,138 @@
+package cache
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dangduoc08/gogo/aggregation"
+	"github.com/dangduoc08/gogo/ctx"
+	"github.com/dangduoc08/gogo/routing"
+	"github.com/dangduoc08/gogo/utils"
+)
+
+func newInterceptorContext(target string) *ctx.Context {
+	c := ctx.NewContext()
+	c.Event = ctx.NewEvent()
+	c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+	c.ResponseWriter = httptest.NewRecorder()
+	c.SetType(ctx.HTTPType)
+
+	return c
+}
+
+// intercept runs interceptor
+// then consumes data as main handler returned it
+func intercept(interceptor CacheInterceptor, c *ctx.Context, handler func(*ctx.Context) any) any {
+	agg := aggregation.NewAggregation()
+	if data := interceptor.Intercept(c, agg); !agg.IsMainHandlerCalled {
+		return data
+	}
+
+	return agg.GetAggregationOperator(aggregation.OPERATOR_CONSUME)(c, handler(c))
+}
+
+func TestCacheInterceptor(t *testing.T) {
+	interceptor := CacheInterceptor{
+		CacheService: CacheService{
+			Store: New[any](CacheOpts{
+				Strategy: LFU,
+				Cap:      10,
+			}),
+			TTL: time.Minute,
+		},
+	}
+
+	handledTotal := 0
+	handler := func(c *ctx.Context) any {
+		handledTotal++
+		c.ResponseWriter.Header().Set("ETag", `"v1"`)
+		c.ResponseWriter.Header().Set("Set-Cookie", "sid=1")
+		c.Status(http.StatusNonAuthoritativeInfo)
+
+		return ctx.Map{"name": "gogo"}
+	}
+
+	intercept(interceptor, newInterceptorContext("/profiles"), handler)
+
+	c := newInterceptorContext("/profiles")
+	data := intercept(interceptor, c, handler)
+	responseHeader := c.ResponseWriter.Header()
+
+	if handledTotal != 1 || data.(ctx.Map)["name"] != "gogo" {
+		t.Errorf(utils.ErrorMessage(handledTotal, 1, "cached data should be returned"))
+	}
+
+	if c.Code != http.StatusNonAuthoritativeInfo {
+		t.Errorf(utils.ErrorMessage(c.Code, http.StatusNonAuthoritativeInfo, "status should be cached"))
+	}
+
+	if etag := responseHeader.Get("ETag"); etag != `"v1"` {
+		t.Errorf(utils.ErrorMessage(etag, `"v1"`, "headers should be cached"))
+	}
+
+	if cookie := responseHeader.Get("Set-Cookie"); cookie != "" {
+		t.Errorf(utils.ErrorMessage(cookie, "", "cookies should not be cached"))
+	}
+
+	if xCache := responseHeader.Get("X-Cache"); xCache != "HIT" {
+		t.Errorf(utils.ErrorMessage(xCache, "HIT", "response should be cache hit"))
+	}
+
+	// readers are drained by first response
+	handledTotal = 0
+	readerHandler := func(c *ctx.Context) any {
+		handledTotal++
+		return strings.NewReader("report")
+	}
+
+	intercept(interceptor, newInterceptorContext("/reports"), readerHandler)
+	intercept(interceptor, newInterceptorContext("/reports"), readerHandler)
+
+	if handledTotal != 2 {
+		t.Errorf(utils.ErrorMessage(handledTotal, 2, "reader should not be cached"))
+	}
+}
+
+func TestCacheInterceptorVaryHostAndVersion(t *testing.T) {
+	interceptor := CacheInterceptor{
+		CacheService: CacheService{
+			Store: New[any](CacheOpts{
+				Strategy: LFU,
+				Cap:      10,
+			}),
+			TTL: time.Minute,
+		},
+	}
+
+	handler := func(c *ctx.Context) any {
+		return c.Request.Host + c.GetRoute()
+	}
+
+	newContext := func(host, route string) *ctx.Context {
+		c := newInterceptorContext("/profiles")
+		c.Request.Host = host
+		c.SetRoute(route)
+
+		return c
+	}
+
+	cases := []*ctx.Context{
+		newContext("api.gogo.dev", "/profiles/[GET]/"),
+		newContext("admin.gogo.dev", "/profiles/[GET]/"),
+		newContext("api.gogo.dev", routing.VersionToRoute("2")+"/profiles/[GET]/"),
+	}
+
+	for _, c := range cases {
+		intercept(interceptor, c, handler)
+	}
+
+	for _, c := range cases {
+		expected := c.Request.Host + c.GetRoute()
+		if data := intercept(interceptor, newContext(c.Request.Host, c.GetRoute()), handler); data != expected {
+			t.Errorf(utils.ErrorMessage(data, expected, "responses of different host or version should not share cache entry"))
+		}
+	}
+}
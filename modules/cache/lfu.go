@@ -43,6 +43,9 @@ func (c *lfu[U, T]) upsertDLLByFreq(f int64) *DLL[object[U]] {
 }
 
 func (c *lfu[U, T]) Get(k string) (U, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	resp, ok := c.values.Load(k)
 	if !ok {
 		var zero U
@@ -51,28 +54,7 @@ func (c *lfu[U, T]) Get(k string) (U, bool) {
 
 	lfuValue := resp.(*lfuValues[U, object[U]])
 	if isExpired(lfuValue.ex) {
-		c.values.Delete(k)
-		atomic.AddInt64(&c.cap, 1)
-
-		ll := c.getDLLByFreq(lfuValue.freq)
-		if ll != nil {
-			ll.delete(lfuValue.node)
-
-			if ll.size == 0 {
-
-				// delete DLL by freq map
-				c.freqMap.Delete(lfuValue.freq)
-
-				var leastF int64 = -1
-				c.freqMap.Range(func(f, value any) bool {
-					if leastF == -1 || leastF < f.(int64) {
-						leastF = f.(int64)
-					}
-					return true
-				})
-				c.leastF = leastF
-			}
-		}
+		c.del(k)
 
 		var zero U
 		return zero, false
@@ -108,8 +90,14 @@ func (c *lfu[U, T]) Set(k string, d U, ex time.Duration) {
 		return
 	}
 
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	var initFreq int64 = 0
 
+	// replace existed value
+	c.del(k)
+
 	if c.cap <= 0 {
 		ll := c.getDLLByFreq(c.leastF)
 		if ll != nil {
@@ -146,13 +134,82 @@ func (c *lfu[U, T]) Set(k string, d U, ex time.Duration) {
 }
 
 func (c *lfu[U, T]) Del(k string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.del(k)
+}
+
+// del deletes value and its node,
+// caller must hold mu
+func (c *lfu[U, T]) del(k string) bool {
+	resp, ok := c.values.LoadAndDelete(k)
+	if !ok {
+		return false
+	}
+	atomic.AddInt64(&c.cap, 1)
+
+	lfuValue := resp.(*lfuValues[U, object[U]])
+	ll := c.getDLLByFreq(lfuValue.freq)
+	if ll != nil {
+		ll.delete(lfuValue.node)
+
+		if ll.size == 0 {
+
+			// delete DLL by freq map
+			c.freqMap.Delete(lfuValue.freq)
+
+			var leastF int64 = -1
+			c.freqMap.Range(func(f, value any) bool {
+				if leastF == -1 || f.(int64) < leastF {
+					leastF = f.(int64)
+				}
+				return true
+			})
+			c.leastF = leastF
+		}
+	}
+
 	return true
 }
 
 func (c *lfu[U, T]) Has(k string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	resp, ok := c.values.Load(k)
+	if !ok {
+		return false
+	}
+
+	if isExpired(resp.(*lfuValues[U, object[U]]).ex) {
+		c.del(k)
+		return false
+	}
+
 	return true
 }
 
+func (c *lfu[U, T]) Keys() []string {
+	keys := []string{}
+	c.values.Range(func(k, value any) bool {
+		if !isExpired(value.(*lfuValues[U, object[U]]).ex) {
+			keys = append(keys, k.(string))
+		}
+		return true
+	})
+
+	return keys
+}
+
 func (c *lfu[U, T]) Clear() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.values.Range(func(k, value any) bool {
+		c.del(k.(string))
+		return true
+	})
+
 	return true
 }
@@ -0,0 +1,58 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/dangduoc08/gogo/core"
+)
+
+type CacheModuleOptions struct {
+	IsGlobal bool
+
+	// maximum number of cached items
+	Cap int64
+
+	// default time to live of cached items,
+	// negative value means items never expire
+	TTL time.Duration
+}
+
+func loadCacheOptions(opts *CacheModuleOptions) *CacheModuleOptions {
+	if opts == nil {
+		opts = &CacheModuleOptions{}
+	}
+
+	cacheOptions := &CacheModuleOptions{
+		IsGlobal: opts.IsGlobal,
+		Cap:      opts.Cap,
+		TTL:      opts.TTL,
+	}
+
+	if cacheOptions.Cap <= 0 {
+		cacheOptions.Cap = 100
+	}
+
+	if cacheOptions.TTL == 0 {
+		cacheOptions.TTL = 5 * time.Second
+	}
+
+	return cacheOptions
+}
+
+func Register(opts *CacheModuleOptions) *core.Module {
+	cacheOptions := loadCacheOptions(opts)
+	cacheService := CacheService{
+		Store: New[any](CacheOpts{
+			Strategy: LFU,
+			Cap:      cacheOptions.Cap,
+		}),
+		TTL: cacheOptions.TTL,
+	}
+
+	module := core.ModuleBuilder().
+		Providers(cacheService).
+		Build()
+
+	module.IsGlobal = cacheOptions.IsGlobal
+	return module
+}
@@ -0,0 +1,101 @@
+package cache
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dangduoc08/gogo/core"
+	"github.com/dangduoc08/gogo/ctx"
+	"github.com/dangduoc08/gogo/routing"
+)
+
+type CacheService struct {
+	Store CacheModuler[any]
+	TTL   time.Duration
+}
+
+func (cacheService CacheService) NewProvider() core.Provider {
+	return cacheService
+}
+
+func (cacheService CacheService) Get(k string) (any, bool) {
+	return cacheService.Store.Get(k)
+}
+
+// Set caches value with default TTL
+// unless ttl was passed
+func (cacheService CacheService) Set(k string, v any, ttl ...time.Duration) {
+	ex := cacheService.TTL
+	if len(ttl) > 0 && ttl[0] != 0 {
+		ex = ttl[0]
+	}
+
+	// store expiry is counted by milliseconds
+	if ex < 0 {
+		cacheService.Store.Set(k, v, -1)
+	} else if ms := ex.Milliseconds(); ms > 0 {
+		cacheService.Store.Set(k, v, time.Duration(ms))
+	}
+}
+
+func (cacheService CacheService) Del(k string) bool {
+	return cacheService.Store.Del(k)
+}
+
+func (cacheService CacheService) Has(k string) bool {
+	return cacheService.Store.Has(k)
+}
+
+func (cacheService CacheService) Keys() []string {
+	return cacheService.Store.Keys()
+}
+
+func (cacheService CacheService) Clear() bool {
+	return cacheService.Store.Clear()
+}
+
+// Invalidate deletes cached responses of path
+// regardless query, host, version and headers
+func (cacheService CacheService) Invalidate(path string) int {
+	total := 0
+	prefix := http.MethodGet + " " + path + "?"
+
+	for _, k := range cacheService.Store.Keys() {
+		if strings.HasPrefix(k, prefix) && cacheService.Store.Del(k) {
+			total++
+		}
+	}
+
+	return total
+}
+
+// GenResponseKey generates key of cached response
+// by method, path, sorted query, host,
+// version of matched route and values of headers
+func GenResponseKey(c *ctx.Context, headers []string) string {
+	r := c.Request
+
+	var sb strings.Builder
+	sb.WriteString(r.Method)
+	sb.WriteString(" ")
+	sb.WriteString(r.URL.Path)
+	sb.WriteString("?")
+	sb.WriteString(r.URL.Query().Encode())
+	sb.WriteString(" Host=")
+	sb.WriteString(strings.ToLower(r.Host))
+
+	if version := routing.RouteVersion(c.GetRoute()); version != "" {
+		sb.WriteString(" Version=")
+		sb.WriteString(version)
+	}
+
+	for _, header := range headers {
+		sb.WriteString(" ")
+		sb.WriteString(http.CanonicalHeaderKey(header))
+		sb.WriteString("=")
+		sb.WriteString(strings.Join(r.Header.Values(header), ","))
+	}
+
+	return sb.String()
+}
@@ -1,6 +1,7 @@
 package cache
 
 import (
+	"strconv"
 	"sync"
 	"testing"
 )
@@ -36,3 +37,40 @@ func TestUpsertDLLRaceCondition(t *testing.T) {
 		t.Errorf("upsertDLLByFreq caused race condition. Total created key expect = %v; output = %v", expect1, output1)
 	}
 }
+
+func TestLFUConcurrentAccess(t *testing.T) {
+	cacheModule := New[int](CacheOpts{
+		Strategy: LFU,
+		Cap:      16,
+	})
+
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 64; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			for j := 0; j < 200; j++ {
+				k := strconv.Itoa((i + j) % 32)
+				cacheModule.Set(k, j, -1)
+				cacheModule.Get(k)
+				cacheModule.Has(k)
+
+				if j%50 == 0 {
+					cacheModule.Del(k)
+				}
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	if total := len(cacheModule.Keys()); total > 16 {
+		t.Errorf("cache exceeded capacity under concurrent access. Total keys expect <= %v; output = %v", 16, total)
+	}
+
+	if cacheModule.Clear(); len(cacheModule.Keys()) != 0 {
+		t.Errorf("cache should be empty after Clear. Total keys = %v", len(cacheModule.Keys()))
+	}
+}
@@ -0,0 +1,42 @@
+package session
+
+import (
+	"time"
+
+	"github.com/dangduoc08/gogo/ctx"
+	"github.com/dangduoc08/gogo/modules/cache"
+)
+
+// CacheStore keeps sessions in cache module,
+// least frequently used sessions are evicted
+// when cache reached its capacity
+type CacheStore struct {
+	CacheService cache.CacheService
+	Prefix       string
+}
+
+func NewCacheStore(cacheService cache.CacheService) CacheStore {
+	return CacheStore{
+		CacheService: cacheService,
+		Prefix:       "session:",
+	}
+}
+
+func (store CacheStore) Get(c *ctx.Context, id string) (map[string]any, bool) {
+	values, ok := store.CacheService.Get(store.Prefix + id)
+	if !ok {
+		return nil, false
+	}
+
+	return copyValues(values.(map[string]any)), true
+}
+
+func (store CacheStore) Set(c *ctx.Context, id string, values map[string]any, ttl time.Duration) error {
+	store.CacheService.Set(store.Prefix+id, copyValues(values), ttl)
+	return nil
+}
+
+func (store CacheStore) Destroy(c *ctx.Context, id string) error {
+	store.CacheService.Del(store.Prefix + id)
+	return nil
+}
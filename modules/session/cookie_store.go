@@ -0,0 +1,111 @@
+package session
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/dangduoc08/gogo/ctx"
+)
+
+// browsers limit cookie size,
+// signature of signed cookies takes 44 bytes
+const (
+	maxCookieSize = 4096
+	signatureSize = 44
+)
+
+// CookieStore keeps session values in client cookie
+// signed by cookie secrets of App,
+// values are readable by client but can't be modified
+type CookieStore struct {
+	Name     string
+	Path     string
+	Domain   string
+	SameSite http.SameSite
+	IsSecure bool
+}
+
+type cookiePayload struct {
+	ID        string         `json:"id"`
+	Values    map[string]any `json:"values"`
+	ExpiredAt int64          `json:"expiredAt"`
+}
+
+func NewCookieStore() CookieStore {
+	return CookieStore{
+		Name:     "session",
+		Path:     "/",
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+func (store CookieStore) Get(c *ctx.Context, id string) (map[string]any, bool) {
+	encodedPayload, ok := c.SignedCookie(store.Name)
+	if !ok {
+		return nil, false
+	}
+
+	jsonPayload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, false
+	}
+
+	payload := cookiePayload{}
+	if err := json.Unmarshal(jsonPayload, &payload); err != nil {
+		return nil, false
+	}
+
+	if payload.ID != id || time.Now().Unix() > payload.ExpiredAt {
+		return nil, false
+	}
+
+	return payload.Values, true
+}
+
+func (store CookieStore) Set(c *ctx.Context, id string, values map[string]any, ttl time.Duration) error {
+	if len(c.GetCookieSecrets()) == 0 {
+		return ctx.ErrCookieSecretsRequired
+	}
+
+	expiredAt := time.Now().Add(ttl)
+	jsonPayload, err := json.Marshal(cookiePayload{
+		ID:        id,
+		Values:    values,
+		ExpiredAt: expiredAt.Unix(),
+	})
+	if err != nil {
+		return err
+	}
+
+	value := base64.RawURLEncoding.EncodeToString(jsonPayload)
+	if len(value)+signatureSize > maxCookieSize {
+		return errors.New("session values exceeded cookie size")
+	}
+
+	cookieOptions := store.newCookieOptions(value)
+	cookieOptions.Expires = expiredAt
+	c.SetSignedCookie(cookieOptions)
+
+	return nil
+}
+
+func (store CookieStore) Destroy(c *ctx.Context, id string) error {
+	c.ClearCookie(store.newCookieOptions(""))
+
+	return nil
+}
+
+func (store CookieStore) newCookieOptions(value string) ctx.CookieOptions {
+	return ctx.CookieOptions{
+		Name:       store.Name,
+		Value:      value,
+		Path:       store.Path,
+		Domain:     store.Domain,
+		SameSite:   store.SameSite,
+		IsSecure:   store.IsSecure,
+		IsHTTPOnly: true,
+	}
+}
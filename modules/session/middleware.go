@@ -0,0 +1,261 @@
+package session
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/dangduoc08/gogo/common"
+	"github.com/dangduoc08/gogo/ctx"
+	"github.com/dangduoc08/gogo/exception"
+	"github.com/dangduoc08/gogo/log"
+)
+
+// SessionOptions configures session cookie and store,
+// session ID is signed by cookie secrets of App
+// which are rotated by App.UseCookieSecrets
+type SessionOptions struct {
+
+	// in-memory store by default
+	Store Store
+	TTL   time.Duration
+
+	// extend session expiry on every request
+	IsRolling bool
+
+	CookieName     string
+	CookiePath     string
+	CookieDomain   string
+	CookieSameSite http.SameSite
+	IsCookieSecure bool
+
+	// store errors which occurred
+	// after response was written are logged
+	Logger common.Logger
+}
+
+func loadSessionOptions(opts SessionOptions) SessionOptions {
+	if opts.Store == nil {
+		opts.Store = NewMemoryStore()
+	}
+
+	if opts.TTL <= 0 {
+		opts.TTL = 24 * time.Hour
+	}
+
+	if opts.CookieName == "" {
+		opts.CookieName = "sid"
+	}
+
+	if opts.CookiePath == "" {
+		opts.CookiePath = "/"
+	}
+
+	if opts.CookieSameSite == 0 {
+		opts.CookieSameSite = http.SameSiteLaxMode
+	}
+
+	if opts.Logger == nil {
+		opts.Logger = log.NewLog(nil)
+	}
+
+	return opts
+}
+
+// Middleware loads session by signed cookie ID
+// and saves it once response is written.
+// WS sessions are loaded from handshake cookie
+// and saved immediately when changed.
+// Store errors raise InternalServerErrorException
+// if response wasn't written yet, otherwise they are logged
+func Middleware(opts SessionOptions) func(*ctx.Context) {
+	sessionOptions := loadSessionOptions(opts)
+
+	return func(c *ctx.Context) {
+		record := &Record{
+			values: map[string]any{},
+		}
+
+		if id, ok := c.SignedCookie(sessionOptions.CookieName); ok {
+			if values, ok := sessionOptions.Store.Get(c, id); ok {
+				record.id = id
+				record.values = values
+			}
+		}
+
+		if record.id == "" {
+			record.id = genID()
+			record.isNew = true
+		}
+
+		newCtx := context.WithValue(c.Request.Context(), sessionKey{}, record)
+		c.Request = c.Request.WithContext(newCtx)
+
+		if c.GetType() == ctx.WSType {
+			record.save = func() {
+				if err := commit(c, record, sessionOptions, false); err != nil {
+					sessionOptions.Logger.Error("SessionError", "error", err.Error())
+				}
+			}
+		} else {
+			sessionWriter := &sessionWriter{
+				ResponseWriter: c.ResponseWriter,
+				commit: func() error {
+					return commit(c, record, sessionOptions, true)
+				},
+				logger: sessionOptions.Logger,
+			}
+			c.ResponseWriter = sessionWriter
+
+			// response was finished,
+			// error can only be logged
+			c.Defer(func() {
+				if err := sessionWriter.commitOnce(); err != nil {
+					sessionWriter.logger.Error("SessionError", "error", err.Error())
+				}
+			})
+		}
+
+		c.Next()
+	}
+}
+
+func commit(c *ctx.Context, record *Record, opts SessionOptions, isSetCookie bool) error {
+	record.mu.Lock()
+	defer record.mu.Unlock()
+
+	isIDChanged := record.isNew || len(record.regeneratedID) > 0
+	for _, id := range record.regeneratedID {
+		if err := opts.Store.Destroy(c, id); err != nil {
+			return err
+		}
+	}
+	record.regeneratedID = nil
+
+	if record.isDestroyed {
+		if !record.isNew {
+			if err := opts.Store.Destroy(c, record.id); err != nil {
+				return err
+			}
+		}
+
+		if isSetCookie {
+			c.ClearCookie(newCookieOptions(opts, ""))
+		}
+		return nil
+	}
+
+	// sessions without values are not saved
+	if !record.isModified && !(opts.IsRolling && !record.isNew) {
+		return nil
+	}
+
+	if isSetCookie && len(c.GetCookieSecrets()) == 0 {
+		return ctx.ErrCookieSecretsRequired
+	}
+
+	if err := opts.Store.Set(c, record.id, record.values, opts.TTL); err != nil {
+		return err
+	}
+
+	record.isNew = false
+	record.isModified = false
+
+	if isSetCookie && (isIDChanged || opts.IsRolling) {
+		cookieOptions := newCookieOptions(opts, record.id)
+		cookieOptions.MaxAge = opts.TTL
+		c.SetSignedCookie(cookieOptions)
+	}
+
+	return nil
+}
+
+func newCookieOptions(opts SessionOptions, value string) ctx.CookieOptions {
+	return ctx.CookieOptions{
+		Name:       opts.CookieName,
+		Value:      value,
+		Path:       opts.CookiePath,
+		Domain:     opts.CookieDomain,
+		SameSite:   opts.CookieSameSite,
+		IsSecure:   opts.IsCookieSecure,
+		IsHTTPOnly: true,
+	}
+}
+
+// sessionWriter saves session
+// before headers are sent
+type sessionWriter struct {
+	http.ResponseWriter
+	commit      func() error
+	logger      common.Logger
+	isCommitted bool
+}
+
+func (w *sessionWriter) commitOnce() error {
+	if w.isCommitted {
+		return nil
+	}
+	w.isCommitted = true
+
+	return w.commit()
+}
+
+// mustCommit raises exception
+// which is handled by exception filters
+// since nothing was sent yet
+func (w *sessionWriter) mustCommit() {
+	if err := w.commitOnce(); err != nil {
+		w.logger.Error("SessionError", "error", err.Error())
+		panic(exception.InternalServerErrorException("Session couldn't be saved"))
+	}
+}
+
+func (w *sessionWriter) WriteHeader(statusCode int) {
+	w.mustCommit()
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *sessionWriter) Write(b []byte) (int, error) {
+	w.mustCommit()
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *sessionWriter) Flush() {
+	w.mustCommit()
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+func (w *sessionWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// CSRFStore keeps CSRF token in session
+// for synchronizer token pattern,
+// session middleware must be applied before CSRF middleware
+type CSRFStore struct {
+	Key string
+}
+
+func (store CSRFStore) Get(c *ctx.Context) string {
+	record, ok := c.Request.Context().Value(sessionKey{}).(*Record)
+	if !ok {
+		return ""
+	}
+
+	token, _ := record.Get(store.key()).(string)
+	return token
+}
+
+func (store CSRFStore) Set(c *ctx.Context, token string) {
+	if record, ok := c.Request.Context().Value(sessionKey{}).(*Record); ok {
+		record.Set(store.key(), token)
+	}
+}
+
+func (store CSRFStore) key() string {
+	if store.Key == "" {
+		return "_csrf"
+	}
+
+	return store.Key
+}
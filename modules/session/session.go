@@ -0,0 +1,172 @@
+package session
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"sync"
+
+	"github.com/dangduoc08/gogo/common"
+	"github.com/dangduoc08/gogo/ctx"
+)
+
+const flashKey = "_flash"
+
+type sessionKey struct{}
+
+var errSessionDestroyed = errors.New("session was destroyed, call Regenerate to start new session")
+
+// Record holds values of current session,
+// changes are saved once response is written
+// or immediately for WS messages
+type Record struct {
+	mu            sync.Mutex
+	id            string
+	values        map[string]any
+	regeneratedID []string
+	isNew         bool
+	isModified    bool
+	isDestroyed   bool
+	save          func()
+}
+
+// Session is injectable argument of REST and WS handlers,
+// session middleware must be applied
+type Session struct {
+	*Record
+}
+
+func (session Session) Transform(c *ctx.Context, metadata common.ArgumentMetadata) any {
+	record, ok := c.Request.Context().Value(sessionKey{}).(*Record)
+	if !ok {
+		panic(errors.New("session middleware was not applied"))
+	}
+
+	return Session{record}
+}
+
+func (record *Record) ID() string {
+	return record.id
+}
+
+func (record *Record) IsNew() bool {
+	return record.isNew
+}
+
+func (record *Record) Get(k string) any {
+	record.mu.Lock()
+	defer record.mu.Unlock()
+
+	return record.values[k]
+}
+
+// Set panics once session was destroyed,
+// call Regenerate to start new session
+func (record *Record) Set(k string, v any) {
+	record.mu.Lock()
+	if record.isDestroyed {
+		record.mu.Unlock()
+		panic(errSessionDestroyed)
+	}
+	record.values[k] = v
+	record.isModified = true
+	record.mu.Unlock()
+
+	record.autoSave()
+}
+
+func (record *Record) Delete(k string) {
+	record.mu.Lock()
+	delete(record.values, k)
+	record.isModified = true
+	record.mu.Unlock()
+
+	record.autoSave()
+}
+
+// Flash sets value which will be deleted
+// once it was read by GetFlash
+func (record *Record) Flash(k string, v any) {
+	record.mu.Lock()
+	if record.isDestroyed {
+		record.mu.Unlock()
+		panic(errSessionDestroyed)
+	}
+	flashes, ok := record.values[flashKey].(map[string]any)
+	if !ok {
+		flashes = map[string]any{}
+		record.values[flashKey] = flashes
+	}
+	flashes[k] = v
+	record.isModified = true
+	record.mu.Unlock()
+
+	record.autoSave()
+}
+
+func (record *Record) GetFlash(k string) any {
+	record.mu.Lock()
+	flashes, ok := record.values[flashKey].(map[string]any)
+	if !ok {
+		record.mu.Unlock()
+		return nil
+	}
+
+	v, ok := flashes[k]
+	if ok {
+		delete(flashes, k)
+		if len(flashes) == 0 {
+			delete(record.values, flashKey)
+		}
+		record.isModified = true
+	}
+	record.mu.Unlock()
+
+	if ok {
+		record.autoSave()
+	}
+
+	return v
+}
+
+// Regenerate changes session ID but keeps values,
+// use it after login to prevent session fixation
+func (record *Record) Regenerate() {
+	record.mu.Lock()
+	if !record.isNew {
+		record.regeneratedID = append(record.regeneratedID, record.id)
+	}
+	record.id = genID()
+	record.isModified = true
+	record.isDestroyed = false
+	record.mu.Unlock()
+
+	record.autoSave()
+}
+
+// Destroy deletes session from store
+// and expires session cookie,
+// values can't be set until Regenerate is called
+func (record *Record) Destroy() {
+	record.mu.Lock()
+	record.values = map[string]any{}
+	record.isDestroyed = true
+	record.mu.Unlock()
+
+	record.autoSave()
+}
+
+func (record *Record) autoSave() {
+	if record.save != nil {
+		record.save()
+	}
+}
+
+func genID() string {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
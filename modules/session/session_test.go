@@ -0,0 +1,195 @@
+package session
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dangduoc08/gogo/common"
+	"github.com/dangduoc08/gogo/ctx"
+	"github.com/dangduoc08/gogo/exception"
+	"github.com/dangduoc08/gogo/utils"
+)
+
+func newSessionContext(secrets []string, cookies ...*http.Cookie) (*ctx.Context, *httptest.ResponseRecorder) {
+	c := ctx.NewContext()
+	c.Event = ctx.NewEvent()
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	for _, cookie := range cookies {
+		c.Request.AddCookie(cookie)
+	}
+	recorder := httptest.NewRecorder()
+	c.ResponseWriter = recorder
+	c.SetType(ctx.HTTPType)
+	c.SetCookieSecrets(secrets)
+
+	return c, recorder
+}
+
+func TestMiddlewareCookieSecrets(t *testing.T) {
+	cases := map[string]Store{
+		"memory store": NewMemoryStore(),
+		"cookie store": NewCookieStore(),
+	}
+
+	for desc, store := range cases {
+		middleware := Middleware(SessionOptions{Store: store})
+
+		c, recorder := newSessionContext([]string{"old_secret"})
+		c.Next = func() {
+			Session{}.Transform(c, common.ArgumentMetadata{}).(Session).Set("user", "gogo")
+			c.JSON(ctx.Map{})
+		}
+		middleware(c)
+		c.Reset()
+
+		cookies := recorder.Result().Cookies()
+
+		// session ID signed by rotated secret
+		// is still verified
+		for secretsDesc, expected := range map[string]any{
+			"new_secret,old_secret": "gogo",
+			"new_secret":            nil,
+		} {
+			c, _ = newSessionContext(strings.Split(secretsDesc, ","), cookies...)
+
+			var user any
+			c.Next = func() {
+				user = Session{}.Transform(c, common.ArgumentMetadata{}).(Session).Get("user")
+			}
+			middleware(c)
+			c.Reset()
+
+			if user != expected {
+				t.Errorf(utils.ErrorMessage(user, expected, desc+" should be verified by cookie secrets "+secretsDesc))
+			}
+		}
+	}
+}
+
+func TestRecordFlash(t *testing.T) {
+	record := &Record{
+		values: map[string]any{},
+	}
+	record.Flash("message", "saved")
+
+	if message := record.GetFlash("message"); message != "saved" {
+		t.Errorf(utils.ErrorMessage(message, "saved", "flash should be read"))
+	}
+
+	if message := record.GetFlash("message"); message != nil {
+		t.Errorf(utils.ErrorMessage(message, nil, "flash should be read once"))
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	store := NewMemoryStore()
+	store.Set(nil, "id_1", map[string]any{"user": "gogo"}, time.Minute)
+	store.Set(nil, "id_2", map[string]any{"user": "gogo"}, -time.Second)
+
+	values, ok := store.Get(nil, "id_1")
+	if !ok || values["user"] != "gogo" {
+		t.Errorf(utils.ErrorMessage(values, map[string]any{"user": "gogo"}, "values should be loaded"))
+	}
+
+	values["user"] = "modified"
+	if values, _ := store.Get(nil, "id_1"); values["user"] != "gogo" {
+		t.Errorf(utils.ErrorMessage(values["user"], "gogo", "values should be copied"))
+	}
+
+	if _, ok := store.Get(nil, "id_2"); ok {
+		t.Errorf(utils.ErrorMessage(ok, false, "session should be expired"))
+	}
+
+	store.Destroy(nil, "id_1")
+	if _, ok := store.Get(nil, "id_1"); ok {
+		t.Errorf(utils.ErrorMessage(ok, false, "session should be destroyed"))
+	}
+}
+
+func TestRecordSetAfterDestroy(t *testing.T) {
+	record := &Record{
+		values: map[string]any{},
+	}
+	record.Destroy()
+
+	func() {
+		defer func() {
+			if rec := recover(); rec != errSessionDestroyed {
+				t.Errorf(utils.ErrorMessage(rec, errSessionDestroyed, "set after destroy should panic"))
+			}
+		}()
+		record.Set("user", "gogo")
+	}()
+
+	record.Regenerate()
+	record.Set("user", "gogo")
+	if user := record.Get("user"); user != "gogo" {
+		t.Errorf(utils.ErrorMessage(user, "gogo", "value should be set after regenerate"))
+	}
+}
+
+type failedStore struct {
+	*MemoryStore
+}
+
+func (store failedStore) Set(c *ctx.Context, id string, values map[string]any, ttl time.Duration) error {
+	return errors.New("store is unavailable")
+}
+
+type recordedLogger struct {
+	errors []string
+}
+
+func (logger *recordedLogger) Debug(msg string, args ...any) {}
+func (logger *recordedLogger) Info(msg string, args ...any)  {}
+func (logger *recordedLogger) Warn(msg string, args ...any)  {}
+func (logger *recordedLogger) Fatal(msg string, args ...any) {}
+func (logger *recordedLogger) Error(msg string, args ...any) {
+	logger.errors = append(logger.errors, msg)
+}
+
+func TestMiddlewareStoreError(t *testing.T) {
+	logger := &recordedLogger{}
+	middleware := Middleware(SessionOptions{
+		Store:  failedStore{NewMemoryStore()},
+		Logger: logger,
+	})
+
+	newContext := func(handler func(*ctx.Context)) *ctx.Context {
+		c, _ := newSessionContext([]string{"secret"})
+		c.Next = func() {
+			Session{}.Transform(c, common.ArgumentMetadata{}).(Session).Set("user", "gogo")
+			handler(c)
+		}
+
+		return c
+	}
+
+	// response wasn't written yet
+	c := newContext(func(c *ctx.Context) {
+		c.JSON(ctx.Map{})
+	})
+	func() {
+		defer func() {
+			httpException, ok := recover().(exception.HTTPException)
+			if code, _ := httpException.GetHTTPStatus(); !ok || code != http.StatusInternalServerError {
+				t.Errorf(utils.ErrorMessage(code, http.StatusInternalServerError, "store error should raise exception"))
+			}
+		}()
+		middleware(c)
+	}()
+
+	// nothing was written by handler,
+	// session is saved by deferred function
+	c = newContext(func(c *ctx.Context) {})
+	middleware(c)
+	c.Reset()
+
+	if len(logger.errors) != 2 {
+		t.Errorf(utils.ErrorMessage(len(logger.errors), 2, "store errors should be logged"))
+	}
+}
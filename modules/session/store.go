@@ -0,0 +1,93 @@
+package session
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dangduoc08/gogo/ctx"
+)
+
+// Store persists session values by ID,
+// implement it to share sessions between instances
+type Store interface {
+	Get(c *ctx.Context, id string) (map[string]any, bool)
+	Set(c *ctx.Context, id string, values map[string]any, ttl time.Duration) error
+	Destroy(c *ctx.Context, id string) error
+}
+
+type memoryRecord struct {
+	values    map[string]any
+	expiredAt time.Time
+}
+
+type MemoryStore struct {
+	mu          sync.Mutex
+	records     map[string]memoryRecord
+	nextSweepAt time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		records: make(map[string]memoryRecord),
+	}
+}
+
+func (store *MemoryStore) Get(c *ctx.Context, id string) (map[string]any, bool) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	r, ok := store.records[id]
+	if !ok || time.Now().After(r.expiredAt) {
+		return nil, false
+	}
+
+	return copyValues(r.values), true
+}
+
+func (store *MemoryStore) Set(c *ctx.Context, id string, values map[string]any, ttl time.Duration) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	now := time.Now()
+	store.sweep(now)
+	store.records[id] = memoryRecord{
+		values:    copyValues(values),
+		expiredAt: now.Add(ttl),
+	}
+
+	return nil
+}
+
+func (store *MemoryStore) Destroy(c *ctx.Context, id string) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	delete(store.records, id)
+	return nil
+}
+
+// remove expired records once per minute
+func (store *MemoryStore) sweep(now time.Time) {
+	if now.Before(store.nextSweepAt) {
+		return
+	}
+	store.nextSweepAt = now.Add(time.Minute)
+
+	for id, r := range store.records {
+		if now.After(r.expiredAt) {
+			delete(store.records, id)
+		}
+	}
+}
+
+func copyValues(values map[string]any) map[string]any {
+	copiedValues := make(map[string]any, len(values))
+	for k, v := range values {
+		if flashes, ok := v.(map[string]any); ok && k == flashKey {
+			v = copyValues(flashes)
+		}
+		copiedValues[k] = v
+	}
+
+	return copiedValues
+}
@@ -0,0 +1,132 @@
+package throttler
+
+import (
+	"math"
+	"time"
+)
+
+type Result struct {
+	IsAllowed bool
+	Limit     int
+	Remaining int
+
+	// time until limit is fully restored
+	ResetAfter time.Duration
+
+	// time until next request is allowed,
+	// only set when request was rejected
+	RetryAfter time.Duration
+}
+
+type Algorithm interface {
+	Take(record *Record, limit int, window time.Duration, now time.Time) Result
+}
+
+var (
+	FixedWindow   Algorithm = fixedWindow{}
+	SlidingWindow Algorithm = slidingWindow{}
+	TokenBucket   Algorithm = tokenBucket{}
+)
+
+// allows limit requests per window,
+// window starts from the first request
+type fixedWindow struct{}
+
+func (fixedWindow) Take(record *Record, limit int, window time.Duration, now time.Time) Result {
+	if record.UpdatedAt.IsZero() || now.Sub(record.UpdatedAt) >= window {
+		record.UpdatedAt = now
+		record.Count = 0
+	}
+
+	result := Result{
+		Limit:      limit,
+		ResetAfter: record.UpdatedAt.Add(window).Sub(now),
+	}
+
+	if record.Count < float64(limit) {
+		record.Count++
+		result.IsAllowed = true
+	} else {
+		result.RetryAfter = result.ResetAfter
+	}
+	result.Remaining = limit - int(record.Count)
+
+	return result
+}
+
+// approximates hits of last window
+// by weighting hits of previous window
+type slidingWindow struct{}
+
+func (slidingWindow) Take(record *Record, limit int, window time.Duration, now time.Time) Result {
+	currentWindow := now.Truncate(window)
+	if !record.UpdatedAt.Equal(currentWindow) {
+		if record.UpdatedAt.Equal(currentWindow.Add(-window)) {
+			record.PrevCount = record.Count
+		} else {
+			record.PrevCount = 0
+		}
+		record.Count = 0
+		record.UpdatedAt = currentWindow
+	}
+
+	elapsed := now.Sub(currentWindow)
+	weight := 1 - float64(elapsed)/float64(window)
+	hits := record.PrevCount*weight + record.Count
+
+	result := Result{
+		Limit:      limit,
+		ResetAfter: window - elapsed,
+	}
+
+	if hits+1 <= float64(limit) {
+		record.Count++
+		hits++
+		result.IsAllowed = true
+	} else if record.Count+1 > float64(limit) {
+		result.RetryAfter = window - elapsed
+	} else {
+
+		// wait until weighted hits of previous window
+		// are low enough for one more hit
+		allowedWeight := (float64(limit) - 1 - record.Count) / record.PrevCount
+		result.RetryAfter = time.Duration((1-allowedWeight)*float64(window)) - elapsed
+	}
+	result.Remaining = int(math.Max(0, math.Floor(float64(limit)-hits)))
+
+	if record.PrevCount > 0 {
+		result.ResetAfter += window
+	}
+
+	return result
+}
+
+// bucket holds up to limit tokens
+// and is refilled limit tokens per window
+type tokenBucket struct{}
+
+func (tokenBucket) Take(record *Record, limit int, window time.Duration, now time.Time) Result {
+	refillRate := float64(limit) / float64(window)
+
+	if record.UpdatedAt.IsZero() {
+		record.Count = float64(limit)
+	} else {
+		record.Count = math.Min(float64(limit), record.Count+float64(now.Sub(record.UpdatedAt))*refillRate)
+	}
+	record.UpdatedAt = now
+
+	result := Result{
+		Limit: limit,
+	}
+
+	if record.Count >= 1 {
+		record.Count--
+		result.IsAllowed = true
+	} else {
+		result.RetryAfter = time.Duration((1 - record.Count) / refillRate)
+	}
+	result.Remaining = int(record.Count)
+	result.ResetAfter = time.Duration((float64(limit) - record.Count) / refillRate)
+
+	return result
+}
@@ -0,0 +1,72 @@
+package throttler
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/dangduoc08/gogo/ctx"
+	"github.com/dangduoc08/gogo/exception"
+)
+
+// ThrottlerGuard rejects REST requests and WS messages
+// which exceeded limit with TooManyRequestsException.
+// zero value fields fallback to options of throttler module
+type ThrottlerGuard struct {
+	ThrottlerService ThrottlerService
+	Limit            int
+	Window           time.Duration
+	Algorithm        Algorithm
+	KeyFunc          KeyFunc
+}
+
+func (instance ThrottlerGuard) CanActivate(c *ctx.Context) bool {
+	limit := instance.Limit
+	if limit <= 0 {
+		limit = instance.ThrottlerService.Limit
+	}
+
+	window := instance.Window
+	if window <= 0 {
+		window = instance.ThrottlerService.Window
+	}
+
+	algorithm := instance.Algorithm
+	if algorithm == nil {
+		algorithm = instance.ThrottlerService.Algorithm
+	}
+
+	keyFunc := instance.KeyFunc
+	if keyFunc == nil {
+		keyFunc = instance.ThrottlerService.KeyFunc
+	}
+
+	// limits are counted separately per handler
+	handlerKey := c.Method + " " + c.GetRoute()
+	if c.GetType() == ctx.WSType && c.WS != nil {
+		handlerKey = c.WS.Message.Event
+	}
+
+	result := instance.ThrottlerService.Take(handlerKey+":"+keyFunc(c), limit, window, algorithm)
+
+	if c.GetType() == ctx.HTTPType {
+		responseHeader := c.ResponseWriter.Header()
+		responseHeader.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+		responseHeader.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		responseHeader.Set("RateLimit-Reset", toSeconds(result.ResetAfter))
+
+		if !result.IsAllowed {
+			responseHeader.Set("Retry-After", toSeconds(max(result.RetryAfter, time.Second)))
+		}
+	}
+
+	if !result.IsAllowed {
+		panic(exception.TooManyRequestsException("Too many requests"))
+	}
+
+	return true
+}
+
+func toSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,54 @@
+package throttler
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/dangduoc08/gogo/ctx"
+)
+
+type KeyFunc = func(*ctx.Context) string
+
+// ByConnection limits REST requests by client IP
+// and WS messages by connection
+func ByConnection(c *ctx.Context) string {
+	if c.GetType() == ctx.WSType && c.WS != nil {
+		return "ws:" + c.WS.GetConnID()
+	}
+
+	return ByIP(c)
+}
+
+func ByIP(c *ctx.Context) string {
+	host, _, err := net.SplitHostPort(c.Request.RemoteAddr)
+	if err != nil {
+		host = c.Request.RemoteAddr
+	}
+
+	return "ip:" + host
+}
+
+// ByHeader limits requests by header value,
+// fallback to ByConnection when header is empty
+func ByHeader(header string) KeyFunc {
+	return func(c *ctx.Context) string {
+		if value := c.Request.Header.Get(header); value != "" {
+			return "header:" + value
+		}
+
+		return ByConnection(c)
+	}
+}
+
+// ByUser limits requests by authenticated user
+// which was set into request context under key,
+// fallback to ByConnection for anonymous requests
+func ByUser(key any) KeyFunc {
+	return func(c *ctx.Context) string {
+		if user := c.Request.Context().Value(key); user != nil {
+			return fmt.Sprintf("user:%v", user)
+		}
+
+		return ByConnection(c)
+	}
+}
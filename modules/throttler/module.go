@@ -0,0 +1,76 @@
+package throttler
+
+import (
+	"time"
+
+	"github.com/dangduoc08/gogo/core"
+)
+
+type ThrottlerModuleOptions struct {
+	IsGlobal bool
+
+	// maximum requests per window,
+	// can be overridden per handler by ThrottlerGuard
+	Limit     int
+	Window    time.Duration
+	Algorithm Algorithm
+	KeyFunc   KeyFunc
+
+	// in-memory storage by default
+	Storage Storage
+}
+
+func loadThrottlerOptions(opts *ThrottlerModuleOptions) *ThrottlerModuleOptions {
+	if opts == nil {
+		opts = &ThrottlerModuleOptions{}
+	}
+
+	throttlerOptions := &ThrottlerModuleOptions{
+		IsGlobal:  opts.IsGlobal,
+		Limit:     opts.Limit,
+		Window:    opts.Window,
+		Algorithm: opts.Algorithm,
+		KeyFunc:   opts.KeyFunc,
+		Storage:   opts.Storage,
+	}
+
+	if throttlerOptions.Limit <= 0 {
+		throttlerOptions.Limit = 10
+	}
+
+	if throttlerOptions.Window <= 0 {
+		throttlerOptions.Window = time.Minute
+	}
+
+	if throttlerOptions.Algorithm == nil {
+		throttlerOptions.Algorithm = FixedWindow
+	}
+
+	if throttlerOptions.KeyFunc == nil {
+		throttlerOptions.KeyFunc = ByConnection
+	}
+
+	if throttlerOptions.Storage == nil {
+		throttlerOptions.Storage = NewMemoryStorage()
+	}
+
+	return throttlerOptions
+}
+
+func Register(opts *ThrottlerModuleOptions) *core.Module {
+	throttlerOptions := loadThrottlerOptions(opts)
+	throttlerService := ThrottlerService{
+		Storage:   throttlerOptions.Storage,
+		Limit:     throttlerOptions.Limit,
+		Window:    throttlerOptions.Window,
+		Algorithm: throttlerOptions.Algorithm,
+		KeyFunc:   throttlerOptions.KeyFunc,
+	}
+
+	module := core.ModuleBuilder().
+		Providers(throttlerService).
+		Build()
+
+	module.IsGlobal = throttlerOptions.IsGlobal
+	return module
+}
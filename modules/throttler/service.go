@@ -0,0 +1,38 @@
+package throttler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dangduoc08/gogo/core"
+)
+
+type ThrottlerService struct {
+	Storage   Storage
+	Limit     int
+	Window    time.Duration
+	Algorithm Algorithm
+	KeyFunc   KeyFunc
+}
+
+func (throttlerService ThrottlerService) NewProvider() core.Provider {
+	return throttlerService
+}
+
+// Take consumes one hit of key
+// by limit per window
+func (throttlerService ThrottlerService) Take(key string, limit int, window time.Duration, algorithm Algorithm) Result {
+	var result Result
+	key = fmt.Sprintf("%T:%v", algorithm, key)
+
+	throttlerService.Storage.Update(key, 2*window, func(record *Record) {
+		result = algorithm.Take(record, limit, window, time.Now())
+	})
+
+	return result
+}
+
+// Reset clears hits of key
+func (throttlerService ThrottlerService) Reset(key string, algorithm Algorithm) {
+	throttlerService.Storage.Delete(fmt.Sprintf("%T:%v", algorithm, key))
+}
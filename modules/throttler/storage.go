@@ -0,0 +1,88 @@
+package throttler
+
+import (
+	"sync"
+	"time"
+)
+
+// Record holds state of a throttled key,
+// meaning of fields depends on algorithm
+type Record struct {
+
+	// hits of current window
+	// or remaining tokens of bucket
+	Count float64
+
+	// hits of previous window
+	PrevCount float64
+
+	// start of current window
+	// or last refilled time of bucket
+	UpdatedAt time.Time
+}
+
+// Storage keeps records of throttled keys.
+// implement it to share limits between instances
+type Storage interface {
+
+	// Update loads record of key,
+	// passes it into fn then saves it atomically.
+	// record will be reset after ttl without updates
+	Update(key string, ttl time.Duration, fn func(*Record))
+	Delete(key string)
+}
+
+type memoryRecord struct {
+	record    Record
+	expiredAt time.Time
+}
+
+type MemoryStorage struct {
+	mu          sync.Mutex
+	records     map[string]*memoryRecord
+	nextSweepAt time.Time
+}
+
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{
+		records: make(map[string]*memoryRecord),
+	}
+}
+
+func (storage *MemoryStorage) Update(key string, ttl time.Duration, fn func(*Record)) {
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+
+	now := time.Now()
+	storage.sweep(now)
+
+	r, ok := storage.records[key]
+	if !ok || now.After(r.expiredAt) {
+		r = &memoryRecord{}
+		storage.records[key] = r
+	}
+
+	fn(&r.record)
+	r.expiredAt = now.Add(ttl)
+}
+
+func (storage *MemoryStorage) Delete(key string) {
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+
+	delete(storage.records, key)
+}
+
+// remove expired records once per minute
+func (storage *MemoryStorage) sweep(now time.Time) {
+	if now.Before(storage.nextSweepAt) {
+		return
+	}
+	storage.nextSweepAt = now.Add(time.Minute)
+
+	for key, r := range storage.records {
+		if now.After(r.expiredAt) {
+			delete(storage.records, key)
+		}
+	}
+}
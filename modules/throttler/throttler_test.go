@@ -0,0 +1,110 @@
+package throttler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dangduoc08/gogo/utils"
+)
+
+func TestFixedWindow(t *testing.T) {
+	record := &Record{}
+	now := time.Now()
+
+	for i := 0; i < 3; i++ {
+		if result := FixedWindow.Take(record, 3, time.Minute, now); !result.IsAllowed || result.Remaining != 2-i {
+			t.Errorf(utils.ErrorMessage(result.Remaining, 2-i, "hit should be allowed"))
+		}
+	}
+
+	result := FixedWindow.Take(record, 3, time.Minute, now.Add(10*time.Second))
+	if result.IsAllowed {
+		t.Errorf(utils.ErrorMessage(result.IsAllowed, false, "hit should be rejected"))
+	}
+
+	if result.RetryAfter != 50*time.Second {
+		t.Errorf(utils.ErrorMessage(result.RetryAfter, 50*time.Second, "retry after should be equal"))
+	}
+
+	if result := FixedWindow.Take(record, 3, time.Minute, now.Add(time.Minute)); !result.IsAllowed {
+		t.Errorf(utils.ErrorMessage(result.IsAllowed, true, "hit of next window should be allowed"))
+	}
+}
+
+func TestSlidingWindow(t *testing.T) {
+	record := &Record{}
+	now := time.Now().Truncate(time.Minute)
+
+	for i := 0; i < 4; i++ {
+		SlidingWindow.Take(record, 4, time.Minute, now)
+	}
+
+	// previous window weight = 0.75
+	// 4 * 0.75 = 3 hits
+	if result := SlidingWindow.Take(record, 4, time.Minute, now.Add(75*time.Second)); !result.IsAllowed {
+		t.Errorf(utils.ErrorMessage(result.IsAllowed, true, "hit should be allowed"))
+	}
+
+	result := SlidingWindow.Take(record, 4, time.Minute, now.Add(75*time.Second))
+	if result.IsAllowed {
+		t.Errorf(utils.ErrorMessage(result.IsAllowed, false, "hit should be rejected"))
+	}
+
+	// 4 * weight + 1 <= 3
+	// weight <= 0.5
+	if result.RetryAfter != 15*time.Second {
+		t.Errorf(utils.ErrorMessage(result.RetryAfter, 15*time.Second, "retry after should be equal"))
+	}
+}
+
+func TestTokenBucket(t *testing.T) {
+	record := &Record{}
+	now := time.Now()
+
+	for i := 0; i < 2; i++ {
+		if result := TokenBucket.Take(record, 2, time.Minute, now); !result.IsAllowed {
+			t.Errorf(utils.ErrorMessage(result.IsAllowed, true, "hit should be allowed"))
+		}
+	}
+
+	result := TokenBucket.Take(record, 2, time.Minute, now)
+	if result.IsAllowed {
+		t.Errorf(utils.ErrorMessage(result.IsAllowed, false, "bucket should be empty"))
+	}
+
+	if result.RetryAfter != 30*time.Second {
+		t.Errorf(utils.ErrorMessage(result.RetryAfter, 30*time.Second, "retry after should be equal"))
+	}
+
+	if result := TokenBucket.Take(record, 2, time.Minute, now.Add(30*time.Second)); !result.IsAllowed {
+		t.Errorf(utils.ErrorMessage(result.IsAllowed, true, "bucket should be refilled"))
+	}
+}
+
+func TestMemoryStorage(t *testing.T) {
+	storage := NewMemoryStorage()
+	increase := func(record *Record) {
+		record.Count++
+	}
+
+	storage.Update("key", time.Minute, increase)
+	storage.Update("key", time.Minute, increase)
+	storage.Update("key", time.Minute, func(record *Record) {
+		if record.Count != 2 {
+			t.Errorf(utils.ErrorMessage(record.Count, 2, "record should be saved"))
+		}
+	})
+
+	storage.Delete("key")
+	storage.Update("key", -time.Second, func(record *Record) {
+		if record.Count != 0 {
+			t.Errorf(utils.ErrorMessage(record.Count, 0, "record should be deleted"))
+		}
+	})
+
+	storage.Update("key", time.Minute, func(record *Record) {
+		if record.Count != 0 {
+			t.Errorf(utils.ErrorMessage(record.Count, 0, "record should be expired"))
+		}
+	})
+}
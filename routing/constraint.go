@@ -0,0 +1,117 @@
+package routing
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/dangduoc08/gogo/utils"
+)
+
+// Constraint restricts values of route param,
+// routes are only matched if param value satisfies constraint.
+// Name identifies constraint in router,
+// constraints of same Name must be same matcher
+type Constraint struct {
+	Name  string
+	Match func(string) bool
+}
+
+var (
+	IntConstraint = Constraint{
+		Name:  "int",
+		Match: regexp.MustCompile(`^-?[0-9]+$`).MatchString,
+	}
+
+	UUIDConstraint = Constraint{
+		Name:  "uuid",
+		Match: regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`).MatchString,
+	}
+
+	SlugConstraint = Constraint{
+		Name:  "slug",
+		Match: regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`).MatchString,
+	}
+
+	AlphaConstraint = Constraint{
+		Name:  "alpha",
+		Match: regexp.MustCompile(`^[a-zA-Z]+$`).MatchString,
+	}
+
+	AlphanumericConstraint = Constraint{
+		Name:  "alphanumeric",
+		Match: regexp.MustCompile(`^[a-zA-Z0-9]+$`).MatchString,
+	}
+)
+
+// RegexConstraint matches whole param value
+// against pattern
+func RegexConstraint(pattern string) Constraint {
+	reg := regexp.MustCompile(`^(?:` + pattern + `)$`)
+
+	return Constraint{
+		Name:  "regex(" + pattern + ")",
+		Match: reg.MatchString,
+	}
+}
+
+// EnumConstraint matches param value
+// which is one of values
+func EnumConstraint(values ...string) Constraint {
+	isAllowed := make(map[string]bool)
+	for _, v := range values {
+		isAllowed[v] = true
+	}
+
+	return Constraint{
+		Name: "enum(" + strings.Join(values, "|") + ")",
+		Match: func(v string) bool {
+			return isAllowed[v]
+		},
+	}
+}
+
+// Constrain restricts param k of route,
+// it must be called before route was added
+func (r *Router) Constrain(route, method, k string, constraint Constraint) *Router {
+	if constraint.Name == "" {
+		panic(fmt.Errorf(
+			utils.FmtRed(
+				"constraint of %v param of %v route must have name",
+				k,
+				route,
+			),
+		))
+	}
+
+	endpoint := ToEndpoint(AddMethodToRoute(route, method))
+
+	if r.Constraints[endpoint] == nil {
+		r.Constraints[endpoint] = make(map[string]Constraint)
+	}
+	r.Constraints[endpoint][k] = constraint
+
+	return r
+}
+
+// getConstraints returns constraints
+// ordered by param positions of endpoint
+func (r *Router) getConstraints(endpoint string, paramKeys map[string][]int) []Constraint {
+	if len(r.Constraints[endpoint]) == 0 {
+		return nil
+	}
+
+	total := 0
+	for _, positions := range paramKeys {
+		total += len(positions)
+	}
+
+	constraints := make([]Constraint, total)
+	for k, constraint := range r.Constraints[endpoint] {
+		for _, position := range paramKeys[k] {
+			constraints[position] = constraint
+		}
+	}
+
+	return constraints
+}
@@ -7,14 +7,16 @@ import (
 	"github.com/dangduoc08/gogo/utils"
 )
 
-func SplitRoute(str string) (string, string) {
-	matchMethodReg := regexp.MustCompile(strings.Join(utils.ArrMap(HTTPMethods, func(el string, i int) string {
-		return "/" + "\\" + "[" + el + "\\" + "]"
-	}), "|"))
+// matchMethodReg matches method segment
+// which ends route e.g. /users/[GET]/,
+// extension methods of routers are matched too
+var matchMethodReg = regexp.MustCompile(`/\[([A-Z]+)\](/?)$`)
 
-	method := matchMethodReg.FindString(str)
-	noMethodRoute := matchMethodReg.ReplaceAllString(str, "")
-	return method[2 : len(method)-1], noMethodRoute[:len(noMethodRoute)-1]
+func SplitRoute(str string) (string, string) {
+	matchedIndexes := matchMethodReg.FindStringSubmatchIndex(str)
+	method := str[matchedIndexes[2]:matchedIndexes[3]]
+	noMethodRoute := str[:matchedIndexes[0]] + str[matchedIndexes[4]:]
+	return method, noMethodRoute[:len(noMethodRoute)-1]
 }
 
 func ToEndpoint(str string) string {
@@ -92,8 +94,9 @@ func matchWildcard(str, route string) bool {
 	return len(str) == 0
 }
 
-// get node which has * at last
-func getLastWildcardNode(node *Trie, methodPattern string) *Trie {
+// get node which has * at last,
+// keep the previous one if node has no wildcard
+func getLastWildcardNode(node *Trie, methodPattern string, lastWildcardNode *Trie) *Trie {
 	if node.Children["*"] != nil {
 		wildcardNode := node.Children["*"]
 
@@ -103,7 +106,7 @@ func getLastWildcardNode(node *Trie, methodPattern string) *Trie {
 		}
 	}
 
-	return nil
+	return lastWildcardNode
 }
 
 func checkRouteContainsParams(route string) bool {
@@ -0,0 +1,101 @@
+package routing
+
+import (
+	"net"
+	"path/filepath"
+	"strings"
+
+	"github.com/dangduoc08/gogo/ctx"
+)
+
+// hostSegment encloses host labels of route,
+// it can't be declared by handlers
+// e.g. {tenant}.example.com/users => /[HOST]/com/example/{tenant}/[HOST]/users
+const hostSegment = "[HOST]"
+
+// HostToRoute converts host pattern into route prefix,
+// labels are reversed so that hosts of same domain
+// share trie nodes
+func HostToRoute(host string) string {
+	host = strings.TrimSpace(host)
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+
+	labels := strings.Split(strings.Trim(host, "."), ".")
+	segs := []string{hostSegment}
+	for i := len(labels) - 1; i >= 0; i-- {
+		label := labels[i]
+
+		// host names are case insensitive
+		// but param keys are not
+		if !strings.HasPrefix(label, "{") {
+			label = strings.ToLower(label)
+		}
+		segs = append(segs, label)
+	}
+	segs = append(segs, hostSegment)
+
+	return "/" + strings.Join(segs, "/")
+}
+
+// SplitHost splits route into host pattern and path,
+// host is empty if route was not bound to host
+func SplitHost(route string) (string, string) {
+	prefix := "/" + hostSegment + "/"
+	if !strings.HasPrefix(route, prefix) {
+		return "", route
+	}
+
+	end := strings.Index(route[len(prefix):], "/"+hostSegment)
+	if end < 0 {
+		return "", route
+	}
+
+	labels := strings.Split(route[len(prefix):len(prefix)+end], "/")
+	for i, j := 0, len(labels)-1; i < j; i, j = i+1, j-1 {
+		labels[i], labels[j] = labels[j], labels[i]
+	}
+
+	path := route[len(prefix)+end+len(hostSegment)+1:]
+	if path == "" {
+		path = "/"
+	}
+
+	return strings.Join(labels, "."), path
+}
+
+func isHostRoute(route string) bool {
+	return strings.HasPrefix(route, "/"+hostSegment+"/")
+}
+
+// MatchHost matches routes bound to host first,
+// then routes which were not bound to any host
+func (r *Router) MatchHost(host, route, method string) (bool, string, map[string][]int, []string, []ctx.Handler) {
+	if isReservedRoute(route) {
+		return false, "", nil, nil, nil
+	}
+
+	return r.matchHost(host, route, method)
+}
+
+func (r *Router) matchHost(host, route, method string) (bool, string, map[string][]int, []string, []ctx.Handler) {
+	if host != "" && r.hasHostRoutes {
+		if hostname, _, err := net.SplitHostPort(host); err == nil {
+			host = hostname
+		}
+
+		if isMatched, matchedRoute, paramKeys, paramVals, handlers := r.match(HostToRoute(strings.ToLower(host))+route, method); isMatched {
+			return isMatched, matchedRoute, paramKeys, paramVals, handlers
+		}
+	}
+
+	return r.match(route, method)
+}
+
+// isReservedRoute reports whether requested route
+// starts with segments which can't be declared by handlers
+func isReservedRoute(route string) bool {
+	route = filepath.Clean(route) + "/"
+	return isHostRoute(route) || isVersionRoute(route)
+}
@@ -0,0 +1,120 @@
+package routing
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/dangduoc08/gogo/utils"
+)
+
+const (
+	PathLenient  = "lenient"
+	PathStrict   = "strict"
+	PathRedirect = "redirect"
+	PathReject   = "reject"
+)
+
+type RouterOptions struct {
+
+	// TrailingSlash is one of PathLenient, PathStrict or PathRedirect,
+	// routes are declared without trailing slash
+	TrailingSlash string
+
+	// DotSegments handles ".", ".." and duplicated slashes,
+	// it is one of PathLenient, PathReject or PathRedirect
+	DotSegments string
+
+	IsCaseInsensitive bool
+
+	// IsEncodedPath matches escaped path
+	// so encoded slashes don't split segments,
+	// params are decoded after matching
+	IsEncodedPath bool
+}
+
+func (r *Router) SetOptions(opts RouterOptions) *Router {
+	if opts.TrailingSlash == "" {
+		opts.TrailingSlash = PathLenient
+	}
+
+	if opts.DotSegments == "" {
+		opts.DotSegments = PathLenient
+	}
+
+	if opts.TrailingSlash != PathLenient &&
+		opts.TrailingSlash != PathStrict &&
+		opts.TrailingSlash != PathRedirect {
+		panic(fmt.Errorf(
+			utils.FmtRed(
+				"%v trailing slash policy is not supported",
+				opts.TrailingSlash,
+			),
+		))
+	}
+
+	if opts.DotSegments != PathLenient &&
+		opts.DotSegments != PathReject &&
+		opts.DotSegments != PathRedirect {
+		panic(fmt.Errorf(
+			utils.FmtRed(
+				"%v dot segments policy is not supported",
+				opts.DotSegments,
+			),
+		))
+	}
+
+	r.Options = opts
+
+	return r
+}
+
+// Canonicalize checks requested path against options,
+// it returns canonical path and policy which must be applied:
+// PathLenient if path can be matched as is,
+// PathReject, PathStrict or PathRedirect otherwise
+func (r *Router) Canonicalize(requestedPath string) (string, string) {
+	canonicalPath := requestedPath
+	isRedirected := false
+
+	if r.Options.DotSegments == PathReject || r.Options.DotSegments == PathRedirect {
+		cleanedPath := path.Clean("/" + requestedPath)
+		if cleanedPath != "/" && strings.HasSuffix(requestedPath, "/") {
+			cleanedPath += "/"
+		}
+
+		if cleanedPath != requestedPath {
+			if r.Options.DotSegments == PathReject {
+				return requestedPath, PathReject
+			}
+			canonicalPath = cleanedPath
+			isRedirected = true
+		}
+	}
+
+	if r.Options.TrailingSlash == PathStrict || r.Options.TrailingSlash == PathRedirect {
+		if len(canonicalPath) > 1 && strings.HasSuffix(canonicalPath, "/") {
+			if r.Options.TrailingSlash == PathStrict {
+				return requestedPath, PathStrict
+			}
+			canonicalPath = strings.TrimRight(canonicalPath, "/")
+			if canonicalPath == "" {
+				canonicalPath = "/"
+			}
+			isRedirected = true
+		}
+	}
+
+	if isRedirected {
+
+		// prevent redirecting to
+		// protocol-relative URL e.g. //evil.com
+		if strings.HasPrefix(canonicalPath, "//") {
+			canonicalPath = "/" + strings.TrimLeft(canonicalPath, "/")
+		}
+
+		return canonicalPath, PathRedirect
+	}
+
+	return requestedPath, PathLenient
+}
@@ -3,6 +3,7 @@ package routing
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"path/filepath"
 	"reflect"
 	"strings"
@@ -39,6 +40,16 @@ var HTTPMethods = []string{
 	SERVE,
 }
 
+// ToHTTPMethod returns HTTP method of route method,
+// extension methods are returned as is
+func ToHTTPMethod(method string) string {
+	if httpMethod, ok := OperationsMapHTTPMethods[method]; ok {
+		return httpMethod
+	}
+
+	return method
+}
+
 const (
 	ADD = iota + 1
 	USE
@@ -59,6 +70,12 @@ type Router struct {
 	List               []string
 	GlobalMiddlewares  []ctx.Handler
 	InjectableHandlers map[string]any
+	Constraints        map[string]map[string]Constraint
+	Options            RouterOptions
+	hasHostRoutes      bool
+	hasVersionRoutes   bool
+	methods            []string
+	isRouteAdded       bool
 }
 
 func NewRouter() *Router {
@@ -67,10 +84,42 @@ func NewRouter() *Router {
 		Hash:               make(map[string]RouterItem),
 		GlobalMiddlewares:  []ctx.Handler{},
 		InjectableHandlers: make(map[string]any),
+		Constraints:        make(map[string]map[string]Constraint),
+		methods:            append([]string{}, HTTPMethods...),
+		Options: RouterOptions{
+			TrailingSlash: PathLenient,
+			DotSegments:   PathLenient,
+		},
+	}
+}
+
+// AddHTTPMethod registers extension method e.g. SEARCH
+// to this router, it panics if routes were added
+func (r *Router) AddHTTPMethod(method string) *Router {
+	if r.isRouteAdded {
+		panic(fmt.Errorf(
+			utils.FmtRed(
+				"%v method must be added before routes",
+				method,
+			),
+		))
+	}
+
+	if !utils.ArrIncludes(r.methods, method) {
+		r.methods = append(r.methods, method)
 	}
+
+	return r
+}
+
+// Methods returns built-in
+// and extension methods of router
+func (r *Router) Methods() []string {
+	return r.methods
 }
 
 func (r *Router) push(route, method string, caller int, handlers ...ctx.Handler) *Router {
+	r.isRouteAdded = true
 	endpoint := ToEndpoint(AddMethodToRoute(route, method))
 	var item RouterItem
 
@@ -139,22 +188,49 @@ func (r *Router) push(route, method string, caller int, handlers ...ctx.Handler)
 		}
 	}
 
+	if isHostRoute(endpoint) {
+		r.hasHostRoutes = true
+
+		if _, path := SplitHost(endpoint); isVersionRoute(path) {
+			r.hasVersionRoutes = true
+		}
+	} else if isVersionRoute(endpoint) {
+		r.hasVersionRoutes = true
+	}
+
 	parsedRoute, paramKey := ParseToParamKey(endpoint)
 	item.isRouteContainsParams = checkRouteContainsParams(parsedRoute)
 	r.Hash[endpoint] = item
-	r.Trie.insert(parsedRoute, '/', r.Hash[endpoint].Index, paramKey, r.Hash[endpoint].Handlers)
+	r.Trie.insert(parsedRoute, '/', r.Hash[endpoint].Index, paramKey, r.Hash[endpoint].Handlers, r.getConstraints(endpoint, paramKey)...)
 
 	return r
 }
 
+// Match matches routes
+// which were not bound to any host
 func (r *Router) Match(route, method string) (bool, string, map[string][]int, []string, []ctx.Handler) {
+	if isReservedRoute(route) {
+		return false, "", nil, nil, nil
+	}
+
+	return r.match(route, method)
+}
+
+func (r *Router) match(route, method string) (bool, string, map[string][]int, []string, []ctx.Handler) {
 	route = strings.Join([]string{filepath.Clean(route), "/[", method, "]/"}, "")
 
 	if matchedRouterHash, ok := r.Hash[route]; ok && !matchedRouterHash.isRouteContainsParams {
 		return ok, route, nil, nil, matchedRouterHash.Handlers
 	}
 
-	i, paramKeys, paramVals, handlers := r.Trie.find(route, method, '/')
+	i, paramKeys, paramVals, handlers := r.Trie.search(route, method, '/', r.Options.IsCaseInsensitive)
+	if r.Options.IsEncodedPath {
+		for j, paramVal := range paramVals {
+			if decodedParamVal, err := url.PathUnescape(paramVal); err == nil {
+				paramVals[j] = decodedParamVal
+			}
+		}
+	}
 	matchedRoute := ""
 	isMatched := false
 	if i > -1 {
@@ -165,8 +241,52 @@ func (r *Router) Match(route, method string) (bool, string, map[string][]int, []
 	return isMatched, matchedRoute, paramKeys, paramVals, handlers
 }
 
+// AllowedMethods returns methods
+// which have handlers for route,
+// empty if no route matched regardless of methods
+func (r *Router) AllowedMethods(route string) []string {
+	return r.AllowedHostMethods("", route)
+}
+
+// AllowedHostMethods returns methods
+// which have handlers for host and route
+func (r *Router) AllowedHostMethods(host, route string) []string {
+	return r.AllowedVersionMethods(host, "", route)
+}
+
+// AllowedVersionMethods returns methods
+// which have handlers for host, version and route
+func (r *Router) AllowedVersionMethods(host, version, route string) []string {
+	allowedMethods := []string{}
+
+	for _, method := range r.methods {
+		if method == SERVE {
+			continue
+		}
+
+		if isMatched, matchedRoute, _, _, _ := r.MatchVersion(host, version, route, method); isMatched && r.Hash[matchedRoute].HandlerIndex > -1 {
+			allowedMethods = append(allowedMethods, method)
+		}
+	}
+
+	return allowedMethods
+}
+
 func (r *Router) Group(prefix string, subRouters ...*Router) *Router {
 	for _, subRouter := range subRouters {
+		for _, method := range subRouter.methods {
+			if !utils.ArrIncludes(r.methods, method) {
+				r.methods = append(r.methods, method)
+			}
+		}
+
+		for route, constraints := range subRouter.Constraints {
+			method, path := SplitRoute(route)
+			for k, constraint := range constraints {
+				r.Constrain(prefix+path, method, k, constraint)
+			}
+		}
+
 		for route, routerItem := range subRouter.Hash {
 			method, path := SplitRoute(route)
 			groupPath := prefix + path
@@ -88,6 +88,256 @@ func TestRouterMatch(t *testing.T) {
 	}
 }
 
+func TestRouterConstraint(t *testing.T) {
+	r := NewRouter()
+	r.Constrain("/users/{id}", http.MethodGet, "id", IntConstraint)
+	r.Constrain("/users/{userId}/posts/{status}", http.MethodGet, "status", EnumConstraint("draft", "published"))
+	r.Constrain("/users/{slug}", http.MethodGet, "slug", SlugConstraint)
+
+	routes := []string{
+		"/users/{id}",
+		"/users/{slug}",
+		"/users/{userId}/posts/{status}",
+		"/users/*",
+	}
+	for _, route := range routes {
+		r.Add(route, http.MethodGet, nil)
+	}
+
+	cases := map[string]string{
+		"/users/123":             "/users/{id}",
+		"/users/gogo-framework":  "/users/{slug}",
+		"/users/Gogo_Framework":  "/users/*",
+		"/users/1/posts/draft":   "/users/{userId}/posts/{status}",
+		"/users/1/posts/deleted": "/users/*",
+	}
+
+	for requestedRoute, expectedRoute := range cases {
+		expectedRoute = AddMethodToRoute(expectedRoute, http.MethodGet)
+		_, actualRoute, _, _, _ := r.Match(requestedRoute, http.MethodGet)
+
+		if actualRoute != expectedRoute {
+			t.Errorf(utils.ErrorMessage(actualRoute, expectedRoute, "routes should be matched"))
+		}
+	}
+
+	gr := NewRouter()
+	gr.Group("/v1", r)
+
+	isMatched, _, _, _, _ := gr.Match("/v1/users/1/posts/deleted", http.MethodGet)
+	if isMatched != true {
+		t.Errorf(utils.ErrorMessage(isMatched, true, "wildcard route should be matched"))
+	}
+
+	_, actualRoute, _, paramValues, _ := gr.Match("/v1/users/1/posts/published", http.MethodGet)
+	expectedRoute := AddMethodToRoute("/v1/users/{userId}/posts/{status}", http.MethodGet)
+	if actualRoute != expectedRoute {
+		t.Errorf(utils.ErrorMessage(actualRoute, expectedRoute, "grouped constraints should be kept"))
+	}
+
+	if len(paramValues) != 2 || paramValues[1] != "published" {
+		t.Errorf(utils.ErrorMessage(paramValues, []string{"1", "published"}, "param values should be equal"))
+	}
+}
+
+func TestRouterConstraintConflict(t *testing.T) {
+	r := NewRouter()
+	r.Constrain("/items/{id}/owners", http.MethodGet, "id", IntConstraint)
+	r.Constrain("/items/{id}/tags", http.MethodGet, "id", IntConstraint)
+	r.Add("/items/{id}/owners", http.MethodGet, nil)
+	r.Add("/items/{id}/tags", http.MethodGet, nil)
+
+	for _, requestedRoute := range []string{"/items/1/owners", "/items/1/tags"} {
+		if isMatched, _, _, _, _ := r.Match(requestedRoute, http.MethodGet); !isMatched {
+			t.Errorf(utils.ErrorMessage(isMatched, true, "routes of same constraint should share node"))
+		}
+	}
+
+	cases := map[string]func(){
+		"constraint without name should panic": func() {
+			NewRouter().Constrain("/items/{id}", http.MethodGet, "id", Constraint{
+				Match: IntConstraint.Match,
+			})
+		},
+		"same named constraints of different matchers should panic": func() {
+			r.Constrain("/items/{id}/comments", http.MethodGet, "id", Constraint{
+				Name: IntConstraint.Name,
+				Match: func(v string) bool {
+					return v == "1"
+				},
+			})
+			r.Add("/items/{id}/comments", http.MethodGet, nil)
+		},
+	}
+
+	for desc, fn := range cases {
+		func() {
+			defer func() {
+				if rec := recover(); rec == nil {
+					t.Errorf(utils.ErrorMessage(rec, "panic", desc))
+				}
+			}()
+			fn()
+		}()
+	}
+}
+
+func TestRouterAllowedMethods(t *testing.T) {
+	r := NewRouter()
+	r.Add("/users", http.MethodGet, nil)
+	r.Add("/users", http.MethodPost, nil)
+	r.Add("/users/{id}", http.MethodDelete, nil)
+	r.For("/users/*", []string{http.MethodPut})(func(c *ctx.Context) {})
+
+	cases := map[string][]string{
+		"/users":   {http.MethodGet, http.MethodPost},
+		"/users/1": {http.MethodDelete},
+		"/feeds":   {},
+	}
+
+	for route, expected := range cases {
+		actual := r.AllowedMethods(route)
+		if fmt.Sprint(actual) != fmt.Sprint(expected) {
+			t.Errorf(utils.ErrorMessage(actual, expected, "allowed methods should be equal"))
+		}
+	}
+}
+
+func TestRouterCanonicalize(t *testing.T) {
+	r := NewRouter()
+	r.SetOptions(RouterOptions{
+		TrailingSlash: PathRedirect,
+		DotSegments:   PathRedirect,
+	})
+
+	cases := map[string][]string{
+		"/users":           {"/users", PathLenient},
+		"/users/":          {"/users", PathRedirect},
+		"/":                {"/", PathLenient},
+		"/a/../users//1/":  {"/users/1", PathRedirect},
+		"//evil.com/":      {"/evil.com", PathRedirect},
+		"/users/./profile": {"/users/profile", PathRedirect},
+	}
+
+	for requestedPath, expected := range cases {
+		canonicalPath, policy := r.Canonicalize(requestedPath)
+		if canonicalPath != expected[0] || policy != expected[1] {
+			t.Errorf(utils.ErrorMessage([]string{canonicalPath, policy}, expected, "canonical path should be equal"))
+		}
+	}
+
+	r.SetOptions(RouterOptions{
+		TrailingSlash: PathStrict,
+		DotSegments:   PathReject,
+	})
+
+	if _, policy := r.Canonicalize("/users/"); policy != PathStrict {
+		t.Errorf(utils.ErrorMessage(policy, PathStrict, "trailing slash should not be matched"))
+	}
+
+	if _, policy := r.Canonicalize("/users/../admin"); policy != PathReject {
+		t.Errorf(utils.ErrorMessage(policy, PathReject, "dot segments should be rejected"))
+	}
+}
+
+func TestRouterMatchOptions(t *testing.T) {
+	r := NewRouter()
+	r.SetOptions(RouterOptions{
+		IsCaseInsensitive: true,
+		IsEncodedPath:     true,
+	})
+	r.Add("/userProfiles/{name}", http.MethodGet, nil)
+	r.Add("/files/*.PDF", http.MethodGet, nil)
+
+	isMatched, _, _, paramValues, _ := r.Match("/USERPROFILES/John%2FDoe", http.MethodGet)
+	if !isMatched {
+		t.Errorf(utils.ErrorMessage(isMatched, true, "route should be matched regardless of letter case"))
+	}
+
+	if len(paramValues) != 1 || paramValues[0] != "John/Doe" {
+		t.Errorf(utils.ErrorMessage(paramValues, []string{"John/Doe"}, "encoded param should be decoded"))
+	}
+
+	if isMatched, _, _, _, _ := r.Match("/files/report.pdf", http.MethodGet); !isMatched {
+		t.Errorf(utils.ErrorMessage(isMatched, true, "wildcard route should be matched regardless of letter case"))
+	}
+}
+
+func TestRouterMatchHost(t *testing.T) {
+	r := NewRouter()
+	r.Add(HostToRoute("{tenant}.Example.com")+"/users/{id}", http.MethodGet, nil)
+	r.Add(HostToRoute("admin.example.com")+"/users/{id}", http.MethodGet, nil)
+	r.Add("/users/{id}", http.MethodGet, nil)
+
+	cases := map[string]string{
+		"acme.example.com":      HostToRoute("{tenant}.example.com") + "/users/{id}",
+		"acme.example.com:8080": HostToRoute("{tenant}.example.com") + "/users/{id}",
+		"ADMIN.example.com":     HostToRoute("admin.example.com") + "/users/{id}",
+		"example.org":           "/users/{id}",
+	}
+
+	for host, expectedRoute := range cases {
+		expectedRoute = AddMethodToRoute(expectedRoute, http.MethodGet)
+		_, actualRoute, _, _, _ := r.MatchHost(host, "/users/1", http.MethodGet)
+
+		if actualRoute != expectedRoute {
+			t.Errorf(utils.ErrorMessage(actualRoute, expectedRoute, "routes should be matched by host"))
+		}
+	}
+
+	_, _, paramKeys, paramValues, _ := r.MatchHost("acme.example.com", "/users/1", http.MethodGet)
+	if tenant := paramValues[paramKeys["tenant"][0]]; tenant != "acme" {
+		t.Errorf(utils.ErrorMessage(tenant, "acme", "host param should be equal"))
+	}
+
+	if isMatched, _, _, _, _ := r.Match(HostToRoute("acme.example.com")+"/users/1", http.MethodGet); isMatched {
+		t.Errorf(utils.ErrorMessage(isMatched, false, "host routes should not be matched by path"))
+	}
+
+	host, path := SplitHost(HostToRoute("{tenant}.example.com") + "/users/{id}")
+	if host != "{tenant}.example.com" || path != "/users/{id}" {
+		t.Errorf(utils.ErrorMessage(host+" "+path, "{tenant}.example.com /users/{id}", "host and path should be split"))
+	}
+}
+
+func TestRouterMatchVersion(t *testing.T) {
+	r := NewRouter()
+	r.Add(VersionToRoute("1")+"/users/{id}", http.MethodGet, nil)
+	r.Add(VersionToRoute("2")+"/users/{id}", http.MethodGet, nil)
+	r.Add(HostToRoute("admin.example.com")+VersionToRoute("2")+"/users/{id}", http.MethodGet, nil)
+	r.Add("/health", http.MethodGet, nil)
+
+	cases := [][]string{
+		{"", "1", "/users/1", VersionToRoute("1") + "/users/{id}"},
+		{"", "2", "/users/1", VersionToRoute("2") + "/users/{id}"},
+		{"admin.example.com", "2", "/users/1", HostToRoute("admin.example.com") + VersionToRoute("2") + "/users/{id}"},
+		{"", "2", "/health", "/health"},
+		{"", "", "/health", "/health"},
+		{"", "3", "/users/1", ""},
+	}
+
+	for _, c := range cases {
+		expectedRoute := ""
+		if c[3] != "" {
+			expectedRoute = AddMethodToRoute(c[3], http.MethodGet)
+		}
+		_, actualRoute, _, _, _ := r.MatchVersion(c[0], c[1], c[2], http.MethodGet)
+
+		if actualRoute != expectedRoute {
+			t.Errorf(utils.ErrorMessage(actualRoute, expectedRoute, "routes should be matched by version"))
+		}
+	}
+
+	if isMatched, _, _, _, _ := r.Match(VersionToRoute("1")+"/users/1", http.MethodGet); isMatched {
+		t.Errorf(utils.ErrorMessage(isMatched, false, "version routes should not be matched by path"))
+	}
+
+	version, path := SplitVersion(VersionToRoute("2") + "/users/{id}")
+	if version != "2" || path != "/users/{id}" {
+		t.Errorf(utils.ErrorMessage(version+" "+path, "2 /users/{id}", "version and path should be split"))
+	}
+}
+
 func TestRouterGroup(t *testing.T) {
 	r1 := NewRouter()
 	case1 := []string{
@@ -360,3 +610,24 @@ func TestRouteToJSON(t *testing.T) {
 	}
 
 }
+
+func TestRouterAddHTTPMethod(t *testing.T) {
+	r := NewRouter().AddHTTPMethod("PURGE")
+	r.Add("/purges", "PURGE", func(c *ctx.Context) {})
+
+	if allowedMethods := r.AllowedHostMethods("", "/purges"); !utils.ArrIncludes(allowedMethods, "PURGE") {
+		t.Errorf(utils.ErrorMessage(allowedMethods, "PURGE", "extension method should be allowed"))
+	}
+
+	// methods belong to router
+	if utils.ArrIncludes(HTTPMethods, "PURGE") || utils.ArrIncludes(NewRouter().Methods(), "PURGE") {
+		t.Errorf(utils.ErrorMessage(HTTPMethods, "built-in methods", "extension method should not leak to other routers"))
+	}
+
+	defer func() {
+		if rec := recover(); rec == nil {
+			t.Errorf(utils.ErrorMessage(rec, "panic", "method added after routes should panic"))
+		}
+	}()
+	r.AddHTTPMethod("BAN")
+}
@@ -2,6 +2,7 @@ package routing
 
 import (
 	"encoding/json"
+	"fmt"
 	"reflect"
 	"runtime"
 	"strings"
@@ -16,10 +17,15 @@ type (
 )
 
 type Trie struct {
-	Children  Node
-	Handlers  []ctx.Handler
-	ParamKeys map[string][]int
-	Index     int
+	Children   Node
+	Handlers   []ctx.Handler
+	ParamKeys  map[string][]int
+	Index      int
+	Constraint *Constraint
+
+	// keys of constrained param children
+	// in insertion order
+	constrainedParams []string
 }
 
 func NewTrie() *Trie {
@@ -43,15 +49,45 @@ func (tr *Trie) len() int {
 	return counter
 }
 
-func (tr *Trie) insert(path string, sep byte, index int, paramKeys map[string][]int, handlers []ctx.Handler) *Trie {
+func (tr *Trie) insert(path string, sep byte, index int, paramKeys map[string][]int, handlers []ctx.Handler, constraints ...Constraint) *Trie {
 	node := tr
 	start := strings.IndexByte(path, sep)
+	paramIndex := 0
 
 	for seg, next := utils.StrSegment(path, sep, start); next > -1; seg, next = utils.StrSegment(path, sep, next) {
+		var constraint *Constraint
+
+		// constrained params are stored
+		// as $name children
+		if seg == "$" {
+			if paramIndex < len(constraints) && constraints[paramIndex].Match != nil {
+				constraint = &constraints[paramIndex]
+				seg += constraint.Name
+			}
+			paramIndex++
+		}
+
 		isExist := node.Children[seg] != nil
 
 		if !isExist {
 			node.Children[seg] = NewTrie()
+
+			if constraint != nil {
+				node.Children[seg].Constraint = constraint
+				node.constrainedParams = append(node.constrainedParams, seg)
+			}
+		} else if constraint != nil &&
+			reflect.ValueOf(node.Children[seg].Constraint.Match).Pointer() != reflect.ValueOf(constraint.Match).Pointer() {
+
+			// same named constraints share node,
+			// so they must be same matcher
+			panic(fmt.Errorf(
+				utils.FmtRed(
+					"%v constraint was registered with different matcher at %v",
+					constraint.Name,
+					path,
+				),
+			))
 		}
 
 		if next == len(path)-1 {
@@ -66,6 +102,10 @@ func (tr *Trie) insert(path string, sep byte, index int, paramKeys map[string][]
 }
 
 func (tr *Trie) find(path, method string, sep byte) (int, map[string][]int, []string, []ctx.Handler) {
+	return tr.search(path, method, sep, false)
+}
+
+func (tr *Trie) search(path, method string, sep byte, isCaseInsensitive bool) (int, map[string][]int, []string, []ctx.Handler) {
 	node := tr
 	var matchedNode *Trie
 	var lastWildcardNode *Trie
@@ -78,18 +118,21 @@ func (tr *Trie) find(path, method string, sep byte) (int, map[string][]int, []st
 	methodPattern := fromMethodtoPattern(method)
 
 	for seg, next := utils.StrSegment(path, sep, start); next > -1; seg, next = utils.StrSegment(path, sep, next) {
-		if node.Children[seg] == nil {
+		child := node.getChild(seg, isCaseInsensitive)
+		if child == nil {
 
 			// Handle segs have paramVals
 			// param have higher priority than wildcard
 			// pushed /lv1/123 => /lv/{id}
-			if node.Children["$"] != nil {
+			// constrained params are checked first
+			// non-matching ones fall through
+			if paramNodes := node.findParamNodes(seg); len(paramNodes) > 0 {
 
 				// handle case param and wildcard on same position
 				// then cannot fallback to wildcard
 				// due to trie already be traversed
 				// we will store temp node and return if no route matched
-				lastWildcardNode = getLastWildcardNode(node, methodPattern)
+				lastWildcardNode = getLastWildcardNode(node, methodPattern, lastWildcardNode)
 
 				// pushed /lv1 => /lv/{id}
 				// but still matched
@@ -100,10 +143,21 @@ func (tr *Trie) find(path, method string, sep byte) (int, map[string][]int, []st
 					break
 				}
 
-				node = node.Children["$"]
+				// trie can't be traversed back
+				// so other matched params are tried
+				// before the last one
+				for _, paramNode := range paramNodes[:len(paramNodes)-1] {
+					subIndex, subParamKeys, subParamVals, subHandlers := paramNode.search(path[next:], method, sep, isCaseInsensitive)
+					if subIndex > -1 {
+						paramVals = append(paramVals, seg)
+						return subIndex, subParamKeys, append(paramVals, subParamVals...), subHandlers
+					}
+				}
+
+				node = paramNodes[len(paramNodes)-1]
 				paramVals = append(paramVals, seg)
 			} else if node.Children["*"] != nil {
-				lastWildcardNode = getLastWildcardNode(node, methodPattern)
+				lastWildcardNode = getLastWildcardNode(node, methodPattern, lastWildcardNode)
 				node = node.Children["*"]
 			} else {
 				isNotMatchAnythings := true
@@ -115,7 +169,8 @@ func (tr *Trie) find(path, method string, sep byte) (int, map[string][]int, []st
 				// if we pushed /lv1/* and /lv1/*/*.html
 				// then /lv1/* will match
 				for route := range node.Children {
-					if matchWildcard(seg, route) {
+					if matchWildcard(seg, route) ||
+						(isCaseInsensitive && matchWildcard(strings.ToLower(seg), strings.ToLower(route))) {
 						node = node.Children[route]
 						isNotMatchAnythings = false
 						break
@@ -141,8 +196,8 @@ func (tr *Trie) find(path, method string, sep byte) (int, map[string][]int, []st
 			// then cannot fallback to wildcard
 			// due to trie already be traversed
 			// we will store temp node and return if no route matched
-			lastWildcardNode = getLastWildcardNode(node, methodPattern)
-			node = node.Children[seg]
+			lastWildcardNode = getLastWildcardNode(node, methodPattern, lastWildcardNode)
+			node = child
 		}
 
 		if next == len(path)-1 {
@@ -174,6 +229,39 @@ func (tr *Trie) find(path, method string, sep byte) (int, map[string][]int, []st
 	return i, paramKeys, paramVals, handlers
 }
 
+// getChild returns static child,
+// letter case is ignored if isCaseInsensitive
+func (tr *Trie) getChild(seg string, isCaseInsensitive bool) *Trie {
+	if child := tr.Children[seg]; child != nil || !isCaseInsensitive {
+		return child
+	}
+
+	for k, child := range tr.Children {
+		if strings.EqualFold(k, seg) {
+			return child
+		}
+	}
+
+	return nil
+}
+
+// findParamNodes returns param children matched seg,
+// constrained params precede unconstrained one
+func (tr *Trie) findParamNodes(seg string) []*Trie {
+	paramNodes := []*Trie{}
+	for _, k := range tr.constrainedParams {
+		if tr.Children[k].Constraint.Match(seg) {
+			paramNodes = append(paramNodes, tr.Children[k])
+		}
+	}
+
+	if tr.Children["$"] != nil {
+		paramNodes = append(paramNodes, tr.Children["$"])
+	}
+
+	return paramNodes
+}
+
 func (tr *Trie) ToJSON() (string, error) {
 	nodeMap := tr.genTrieMap("")
 	b, err := json.Marshal(nodeMap)
@@ -0,0 +1,63 @@
+package routing
+
+import (
+	"strings"
+
+	"github.com/dangduoc08/gogo/ctx"
+)
+
+// versionSegment precedes version of route,
+// it can't be declared by handlers
+// e.g. /[VERSION]/2/users
+const versionSegment = "[VERSION]"
+
+func VersionToRoute(version string) string {
+	return "/" + versionSegment + "/" + version
+}
+
+// SplitVersion splits route into version and path,
+// host bound route is split by SplitHost first
+func SplitVersion(route string) (string, string) {
+	prefix := "/" + versionSegment + "/"
+	if !strings.HasPrefix(route, prefix) {
+		return "", route
+	}
+
+	version, path, _ := strings.Cut(route[len(prefix):], "/")
+
+	return version, "/" + path
+}
+
+// RouteVersion returns version of matched route,
+// route may be bound to host and method
+func RouteVersion(route string) string {
+	prefix := "/" + versionSegment + "/"
+	i := strings.Index(route, prefix)
+	if i < 0 {
+		return ""
+	}
+
+	version, _, _ := strings.Cut(route[i+len(prefix):], "/")
+
+	return version
+}
+
+func isVersionRoute(route string) bool {
+	return strings.HasPrefix(route, "/"+versionSegment+"/")
+}
+
+// MatchVersion matches routes of version first,
+// then routes which were not bound to any version
+func (r *Router) MatchVersion(host, version, route, method string) (bool, string, map[string][]int, []string, []ctx.Handler) {
+	if isReservedRoute(route) {
+		return false, "", nil, nil, nil
+	}
+
+	if version != "" && r.hasVersionRoutes {
+		if isMatched, matchedRoute, paramKeys, paramVals, handlers := r.matchHost(host, VersionToRoute(version)+route, method); isMatched {
+			return isMatched, matchedRoute, paramKeys, paramVals, handlers
+		}
+	}
+
+	return r.matchHost(host, route, method)
+}